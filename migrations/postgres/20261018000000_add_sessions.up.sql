@@ -0,0 +1,17 @@
+create table if not exists app.sessions
+(
+    id                          text                     not null primary key,
+    user_id                     integer                  not null,
+    refresh_token_hash          text                     not null,
+    previous_refresh_token_hash text,
+    user_agent                  text                     not null default '',
+    ip                          text                     not null default '',
+    created_at                  timestamp with time zone not null default now(),
+    last_used_at                timestamp with time zone not null default now(),
+    expires_at                  timestamp with time zone not null,
+    revoked_at                  timestamp with time zone
+);
+
+create unique index if not exists sessions_refresh_token_hash_idx on app.sessions (refresh_token_hash);
+create index if not exists sessions_previous_refresh_token_hash_idx on app.sessions (previous_refresh_token_hash);
+create index if not exists sessions_user_idx on app.sessions (user_id);
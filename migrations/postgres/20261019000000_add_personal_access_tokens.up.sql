@@ -0,0 +1,17 @@
+create table if not exists app.personal_access_tokens
+(
+    id           bigserial primary key,
+    user_id      integer                  not null,
+    name         text                     not null,
+    token_hash   text                     not null,
+    token_prefix text                     not null,
+    database_ids jsonb,
+    access       text                     not null,
+    created_at   timestamp with time zone not null default now(),
+    last_used_at timestamp with time zone,
+    expires_at   timestamp with time zone,
+    revoked_at   timestamp with time zone
+);
+
+create unique index if not exists personal_access_tokens_token_hash_idx on app.personal_access_tokens (token_hash);
+create index if not exists personal_access_tokens_user_idx on app.personal_access_tokens (user_id);
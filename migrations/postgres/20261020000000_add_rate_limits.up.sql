@@ -0,0 +1,8 @@
+create table if not exists app.rate_limits
+(
+    key               text                     not null primary key,
+    hits              integer                  not null,
+    window_started_at timestamp with time zone not null
+);
+
+create index if not exists rate_limits_window_started_at_idx on app.rate_limits (window_started_at);
@@ -0,0 +1,4 @@
+drop table if exists app.user_tokens;
+
+alter table app.users
+    drop column if exists email_verified_at;
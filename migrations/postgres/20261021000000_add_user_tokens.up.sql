@@ -0,0 +1,17 @@
+alter table app.users
+    add column if not exists email_verified_at timestamp with time zone;
+
+create table if not exists app.user_tokens
+(
+    id         bigserial primary key,
+    user_id    integer                  not null,
+    purpose    text                     not null,
+    token_hash text                     not null,
+    email      text                     not null,
+    created_at timestamp with time zone not null default now(),
+    expires_at timestamp with time zone not null,
+    used_at    timestamp with time zone
+);
+
+create unique index if not exists user_tokens_token_hash_idx on app.user_tokens (token_hash);
+create index if not exists user_tokens_user_purpose_idx on app.user_tokens (user_id, purpose);
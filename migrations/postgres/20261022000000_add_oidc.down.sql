@@ -0,0 +1,2 @@
+drop table if exists app.oidc_login_requests;
+drop table if exists app.user_identities;
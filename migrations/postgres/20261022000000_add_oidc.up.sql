@@ -0,0 +1,20 @@
+create table if not exists app.user_identities
+(
+    issuer     text                     not null,
+    subject    text                     not null,
+    user_id    integer                  not null,
+    email      text                     not null,
+    created_at timestamp with time zone not null default now(),
+    primary key (issuer, subject)
+);
+
+create index if not exists user_identities_user_idx on app.user_identities (user_id);
+
+create table if not exists app.oidc_login_requests
+(
+    state         text primary key,
+    nonce         text                     not null,
+    code_verifier text                     not null,
+    created_at    timestamp with time zone not null default now(),
+    expires_at    timestamp with time zone not null
+);
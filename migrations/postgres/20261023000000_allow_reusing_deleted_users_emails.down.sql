@@ -0,0 +1,4 @@
+drop index if exists app.users_email_active_idx;
+
+alter table app.users
+    add constraint users_email_key unique (email);
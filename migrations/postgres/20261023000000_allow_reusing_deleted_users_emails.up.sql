@@ -0,0 +1,4 @@
+alter table app.users
+    drop constraint if exists users_email_key;
+
+create unique index if not exists users_email_active_idx on app.users (email) where deleted_at is null;
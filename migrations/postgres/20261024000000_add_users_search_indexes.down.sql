@@ -0,0 +1,3 @@
+drop index if exists app.users_databases_database_idx;
+drop index if exists app.users_email_trgm_idx;
+drop index if exists app.users_name_trgm_idx;
@@ -0,0 +1,3 @@
+create index if not exists users_name_trgm_idx on app.users using gin (name gin_trgm_ops);
+create index if not exists users_email_trgm_idx on app.users using gin (email gin_trgm_ops);
+create index if not exists users_databases_database_idx on app.users_databases (database_id);
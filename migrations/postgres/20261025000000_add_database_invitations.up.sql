@@ -0,0 +1,18 @@
+create table if not exists app.database_invitations
+(
+    id           bigserial primary key,
+    database_id  integer                  not null,
+    email        text                     not null,
+    role         text                     not null,
+    invited_by   integer                  not null,
+    user_id      integer,
+    status       text                     not null default 'pending',
+    created_at   timestamp with time zone not null default now(),
+    expires_at   timestamp with time zone not null,
+    responded_at timestamp with time zone
+);
+
+create unique index if not exists database_invitations_pending_idx
+    on app.database_invitations (database_id, lower(email)) where status = 'pending';
+create index if not exists database_invitations_user_idx on app.database_invitations (user_id) where status = 'pending';
+create index if not exists database_invitations_email_idx on app.database_invitations (lower(email)) where status = 'pending';
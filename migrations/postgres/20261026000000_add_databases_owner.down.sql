@@ -0,0 +1,2 @@
+alter table app.databases
+    drop column if exists owner_id;
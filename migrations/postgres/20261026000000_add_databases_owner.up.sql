@@ -0,0 +1,12 @@
+alter table app.databases
+    add column if not exists owner_id integer;
+
+update app.databases db
+set owner_id = (select udb.user_id
+                from app.users_databases udb
+                where udb.database_id = db.id
+                  and udb.role = 'admin'
+                  and udb.deleted_at is null
+                order by udb.created_at, udb.user_id
+                limit 1)
+where db.owner_id is null;
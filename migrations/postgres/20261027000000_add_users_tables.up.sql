@@ -0,0 +1,13 @@
+create table if not exists app.users_tables
+(
+    user_id    integer                  not null,
+    table_id   text                     not null,
+    role       text                     not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+alter table app.users_tables
+    add constraint users_tables_pkey primary key (user_id, table_id);
+
+create index if not exists users_tables_table_idx on app.users_tables (table_id);
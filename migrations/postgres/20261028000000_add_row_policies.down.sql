@@ -0,0 +1,14 @@
+do
+$$
+    declare
+        t record;
+    begin
+        for t in select id from app.tables
+            loop
+                execute format('alter table users_tablespace.%I drop column if exists created_by', t.id);
+            end loop;
+    end
+$$;
+
+alter table app.tables
+    drop column if exists row_policies;
@@ -0,0 +1,22 @@
+alter table app.tables
+    add column if not exists row_policies jsonb not null default '[]';
+
+do
+$$
+    declare
+        t record;
+    begin
+        for t in select id from app.tables
+            loop
+                execute format('alter table users_tablespace.%I add column if not exists created_by integer', t.id);
+                execute format('update users_tablespace.%I as r
+                                set created_by = cl.user_id
+                                from app.changelog cl
+                                where cl.table_id = %L
+                                  and cl.row_id = r.id
+                                  and cl.change ->> ''changed_entity'' = ''row''
+                                  and cl.change -> ''row_change'' ->> ''change_type'' = ''add''',
+                               t.id, t.id);
+            end loop;
+    end
+$$;
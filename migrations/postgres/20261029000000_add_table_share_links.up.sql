@@ -0,0 +1,17 @@
+create table if not exists app.table_share_links
+(
+    id            bigserial primary key,
+    table_id      text                     not null,
+    created_by    integer                  not null,
+    token_hash    text                     not null,
+    token_prefix  text                     not null,
+    password_hash text,
+    column_ids    jsonb,
+    live          boolean                  not null default false,
+    created_at    timestamp with time zone not null default now(),
+    expires_at    timestamp with time zone,
+    revoked_at    timestamp with time zone
+);
+
+create unique index if not exists table_share_links_token_hash_idx on app.table_share_links (token_hash);
+create index if not exists table_share_links_table_idx on app.table_share_links (table_id) where revoked_at is null;
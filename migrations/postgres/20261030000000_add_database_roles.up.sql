@@ -0,0 +1,11 @@
+create table if not exists app.database_roles
+(
+    id          bigserial primary key,
+    database_id integer                  not null,
+    name        text                     not null,
+    permissions jsonb                    not null default '[]',
+    created_at  timestamp with time zone not null default now(),
+    updated_at  timestamp with time zone not null default now()
+);
+
+create unique index if not exists database_roles_name_idx on app.database_roles (database_id, name);
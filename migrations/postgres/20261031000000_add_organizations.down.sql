@@ -0,0 +1,5 @@
+drop index if exists app.databases_organization_idx;
+alter table app.databases
+    drop column if exists organization_id;
+drop table if exists app.users_organizations;
+drop table if exists app.organizations;
@@ -0,0 +1,25 @@
+create table if not exists app.organizations
+(
+    id         bigserial primary key,
+    name       text                     not null,
+    created_at timestamp with time zone not null default now()
+);
+
+create table if not exists app.users_organizations
+(
+    user_id         integer                  not null,
+    organization_id integer                  not null,
+    role            text                     not null,
+    created_at      timestamp with time zone not null default now(),
+    updated_at      timestamp with time zone not null default now()
+);
+
+alter table app.users_organizations
+    add constraint users_organizations_pkey primary key (user_id, organization_id);
+
+create index if not exists users_organizations_organization_idx on app.users_organizations (organization_id);
+
+alter table app.databases
+    add column if not exists organization_id integer;
+
+create index if not exists databases_organization_idx on app.databases (organization_id);
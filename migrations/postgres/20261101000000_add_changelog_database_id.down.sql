@@ -0,0 +1,4 @@
+drop index if exists app.changelog_target_database_idx;
+delete from app.changelog where target = 'database';
+alter table app.changelog
+    drop column if exists database_id;
@@ -0,0 +1,4 @@
+alter table app.changelog
+    add column if not exists database_id integer;
+
+create index if not exists changelog_target_database_idx on app.changelog (target, database_id, changed_at);
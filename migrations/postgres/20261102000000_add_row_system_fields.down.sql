@@ -0,0 +1,15 @@
+do
+$$
+    declare
+        t record;
+    begin
+        for t in select id from app.tables
+            loop
+                execute format('alter table users_tablespace.%I
+                                    drop column if exists created_at,
+                                    drop column if exists updated_at,
+                                    drop column if exists updated_by',
+                               t.id);
+            end loop;
+    end
+$$;
@@ -0,0 +1,38 @@
+do
+$$
+    declare
+        t record;
+    begin
+        for t in select id, created_at from app.tables
+            loop
+                execute format('alter table users_tablespace.%I
+                                    add column if not exists created_at timestamp with time zone not null default now(),
+                                    add column if not exists updated_at timestamp with time zone not null default now(),
+                                    add column if not exists updated_by integer',
+                               t.id);
+                -- rows without a changelog, e.g. imported ones, date back to the table creation
+                execute format('update users_tablespace.%I
+                                set created_at = %L, updated_at = %L, updated_by = created_by',
+                               t.id, t.created_at, t.created_at);
+                execute format('update users_tablespace.%I as r
+                                set created_at = cl.changed_at,
+                                    updated_at = cl.changed_at
+                                from app.changelog cl
+                                where cl.table_id = %L
+                                  and cl.row_id = r.id
+                                  and cl.change ->> ''changed_entity'' = ''row''
+                                  and cl.change -> ''row_change'' ->> ''change_type'' = ''add''',
+                               t.id, t.id);
+                execute format('update users_tablespace.%I as r
+                                set updated_at = cl.changed_at,
+                                    updated_by = cl.user_id
+                                from (select distinct on (row_id) row_id, user_id, changed_at
+                                      from app.changelog
+                                      where target = ''cell''
+                                        and table_id = %L
+                                      order by row_id, changed_at desc) cl
+                                where cl.row_id = r.id',
+                               t.id, t.id);
+            end loop;
+    end
+$$;
@@ -3,10 +3,15 @@ package app
 import (
 	"backend/src/handlers"
 	"backend/src/handlers/changelog"
+	"backend/src/handlers/common"
 	"backend/src/handlers/databases"
 	"backend/src/handlers/events"
+	"backend/src/handlers/invitations"
+	"backend/src/handlers/organizations"
+	"backend/src/handlers/shares"
 	"backend/src/handlers/tables"
 	"backend/src/handlers/users"
+	"backend/src/modules/rate_limiter"
 	"backend/src/modules/web_sockets"
 	"net/http"
 	"time"
@@ -15,6 +20,15 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	defaultAnonymousRateLimits = []rate_limiter.Rule{
+		{Name: "default", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 300, Window: time.Minute}},
+	}
+	defaultAuthorizedRateLimits = []rate_limiter.Rule{
+		{Name: "default", KeyBy: rate_limiter.KeyByUser, Limit: rate_limiter.Limit{Requests: 1200, Window: time.Minute}},
+	}
+)
+
 func (a *App) initWebServer() {
 	r := gin.Default()
 
@@ -36,16 +50,29 @@ func (a *App) initWebServer() {
 	authMiddleware := a.Services.AuthService.JWTAuthMiddleware()
 	for _, handler := range a.initHandlers() {
 		h := make([]gin.HandlerFunc, 0)
+		rateLimits := defaultAnonymousRateLimits
 		if handler.AuthRequired() {
 			h = append(h, authMiddleware)
+			rateLimits = defaultAuthorizedRateLimits
 		}
+		if rateLimitedHandler, ok := handler.(handlers.IRateLimitedHandler); ok {
+			rateLimits = append(rateLimitedHandler.RateLimits(), rateLimits...)
+		}
+		h = append(h, rate_limiter.Middleware(a.Resources.RateLimiter, rateLimits))
 		h = append(h, handler.Handle)
 
 		r.Handle(handler.Method(), handler.Path(), h...)
 	}
 
-	r.GET("/ws/tables", web_sockets.ServeWS(a.Resources.TablesWSHub))
-	r.GET("/ws/users", web_sockets.ServeWS(a.Resources.UsersWSHub))
+	r.GET("/ws/tables", authMiddleware, web_sockets.ServeWS(
+		a.Resources.TablesWSHub,
+		common.NewTablesWSAuthorizer(a.Services.TablesService, a.Services.DatabasesService),
+	))
+	r.GET("/ws/users", authMiddleware, web_sockets.ServeWS(
+		a.Resources.UsersWSHub,
+		common.NewUsersWSAuthorizer(),
+	))
+	a.Resources.TablesWSHub.OnBroadcast(shares.NewTablesRelay(a.Resources.SharedTablesWSHub))
 
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -65,18 +92,49 @@ func (a *App) initHandlers() []handlers.IHandler {
 		a.Services.DatabasesService,
 		a.Services.FileService,
 		a.Services.ChangelogService,
+		a.Services.ShareLinksService,
 		a.Resources.TablesWSHub,
 		a.Resources.UsersWSHub,
+		a.Resources.SharedTablesWSHub,
 	)...)
 	res = append(res, databases.NewHandlers(
 		a.Services.TablesService,
 		a.Services.DatabasesService,
 		a.Services.UsersService,
+		a.Services.InvitationsService,
+		a.Services.OrganizationsService,
+		a.Resources.TablesWSHub,
+		a.Resources.UsersWSHub,
+	)...)
+	res = append(res, users.NewHandlers(
+		a.Services.AuthService,
+		a.Services.UsersService,
+		a.Services.InvitationsService,
+		a.Services.DatabasesService,
+		a.Services.TablesService,
+		a.Resources.TablesWSHub,
+		a.Resources.UsersWSHub,
+	)...)
+	res = append(res, invitations.NewHandlers(
+		a.Services.InvitationsService,
+		a.Services.UsersService,
 		a.Resources.UsersWSHub,
 	)...)
-	res = append(res, users.NewHandlers(a.Services.AuthService, a.Services.UsersService)...)
 	res = append(res, changelog.NewHandlers(a.Services.ChangelogService, a.Services.TablesService, a.Services.DatabasesService)...)
 	res = append(res, events.NewHandlers(a.Services.UsersService, a.Resources.TablesWSHub)...)
+	res = append(res, organizations.NewHandlers(
+		a.Services.OrganizationsService,
+		a.Services.DatabasesService,
+		a.Services.TablesService,
+		a.Services.UsersService,
+		a.Resources.TablesWSHub,
+		a.Resources.UsersWSHub,
+	)...)
+	res = append(res, shares.NewHandlers(
+		a.Services.ShareLinksService,
+		a.Services.TablesService,
+		a.Resources.SharedTablesWSHub,
+	)...)
 
 	return res
 }
@@ -6,10 +6,20 @@ import (
 )
 
 type Repositories struct {
-	UsersRepository     repositories.IUsersRepository
-	TablesRepository    repositories.ITablesRepository
-	DatabasesRepository repositories.IDatabasesRepository
-	ChangelogRepository repositories.IChangelogRepository
+	UsersRepository                repositories.IUsersRepository
+	TablesRepository               repositories.ITablesRepository
+	DatabasesRepository            repositories.IDatabasesRepository
+	ChangelogRepository            repositories.IChangelogRepository
+	SessionsRepository             repositories.ISessionsRepository
+	PersonalAccessTokensRepository repositories.IPersonalAccessTokensRepository
+	UserTokensRepository           repositories.IUserTokensRepository
+	UserIdentitiesRepository       repositories.IUserIdentitiesRepository
+	OIDCLoginRequestsRepository    repositories.IOIDCLoginRequestsRepository
+	DatabaseInvitationsRepository  repositories.IDatabaseInvitationsRepository
+	UsersTablesRepository          repositories.IUsersTablesRepository
+	TableShareLinksRepository      repositories.ITableShareLinksRepository
+	DatabaseRolesRepository        repositories.IDatabaseRolesRepository
+	OrganizationsRepository        repositories.IOrganizationsRepository
 }
 
 func NewRepositories(res *resources.Resources) *Repositories {
@@ -19,6 +29,16 @@ func NewRepositories(res *resources.Resources) *Repositories {
 	r.TablesRepository = repositories.NewTablesRepository(res.PostgresExecutor)
 	r.DatabasesRepository = repositories.NewDatabasesRepository(res.PostgresExecutor)
 	r.ChangelogRepository = repositories.NewChangelogRepository(res.PostgresExecutor)
+	r.SessionsRepository = repositories.NewSessionsRepository(res.PostgresExecutor)
+	r.PersonalAccessTokensRepository = repositories.NewPersonalAccessTokensRepository(res.PostgresExecutor)
+	r.UserTokensRepository = repositories.NewUserTokensRepository(res.PostgresExecutor)
+	r.UserIdentitiesRepository = repositories.NewUserIdentitiesRepository(res.PostgresExecutor)
+	r.OIDCLoginRequestsRepository = repositories.NewOIDCLoginRequestsRepository(res.PostgresExecutor)
+	r.DatabaseInvitationsRepository = repositories.NewDatabaseInvitationsRepository(res.PostgresExecutor)
+	r.UsersTablesRepository = repositories.NewUsersTablesRepository(res.PostgresExecutor)
+	r.TableShareLinksRepository = repositories.NewTableShareLinksRepository(res.PostgresExecutor)
+	r.DatabaseRolesRepository = repositories.NewDatabaseRolesRepository(res.PostgresExecutor)
+	r.OrganizationsRepository = repositories.NewOrganizationsRepository(res.PostgresExecutor)
 
 	return r
 }
@@ -1,18 +1,27 @@
 package resources
 
 import (
+	"backend/src/modules/oidc"
+	"backend/src/modules/password_hasher"
+	"backend/src/modules/rate_limiter"
 	"backend/src/modules/sql_executor"
 	"backend/src/modules/web_sockets"
 	"context"
 	"os"
+	"strconv"
+	"strings"
 )
 
 type Resources struct {
-	Ctx              context.Context
-	Cancel           context.CancelFunc
-	PostgresExecutor sql_executor.ISQLExecutor
-	TablesWSHub      *web_sockets.Hub
-	UsersWSHub       *web_sockets.Hub
+	Ctx               context.Context
+	Cancel            context.CancelFunc
+	PostgresExecutor  sql_executor.ISQLExecutor
+	TablesWSHub       *web_sockets.Hub
+	UsersWSHub        *web_sockets.Hub
+	SharedTablesWSHub *web_sockets.Hub
+	PasswordHasher    password_hasher.IPasswordHasher
+	RateLimiter       rate_limiter.IRateLimiter
+	OIDCProvider      oidc.IProvider
 }
 
 func NewResources() *Resources {
@@ -27,6 +36,27 @@ func NewResources() *Resources {
 
 	r.TablesWSHub = web_sockets.NewHub(r.Ctx)
 	r.UsersWSHub = web_sockets.NewHub(r.Ctx)
+	r.SharedTablesWSHub = web_sockets.NewHub(r.Ctx)
+
+	passwordHashCost, _ := strconv.Atoi(os.Getenv("PASSWORD_HASH_COST"))
+	r.PasswordHasher = password_hasher.NewBcryptHasher(passwordHashCost)
+
+	switch os.Getenv("RATE_LIMIT_STORAGE") {
+	case "postgres":
+		r.RateLimiter = rate_limiter.NewPostgresRateLimiter(r.Ctx, r.PostgresExecutor)
+	default:
+		r.RateLimiter = rate_limiter.NewMemoryRateLimiter(r.Ctx)
+	}
+
+	if issuerURL := os.Getenv("OIDC_ISSUER_URL"); issuerURL != "" {
+		r.OIDCProvider = oidc.NewProvider(oidc.Config{
+			IssuerURL:    issuerURL,
+			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv("OIDC_SCOPES")),
+		})
+	}
 
 	return r
 }
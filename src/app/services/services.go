@@ -8,28 +8,93 @@ import (
 	"backend/src/services/changelog"
 	"backend/src/services/databases"
 	"backend/src/services/file_service"
+	"backend/src/services/invitations"
+	"backend/src/services/mailer"
+	"backend/src/services/organizations"
+	"backend/src/services/share_links"
 	"backend/src/services/tables"
 	"backend/src/services/users"
+	"os"
 )
 
 type Services struct {
-	UsersService     services.IUsersService
-	ChangelogService services.IChangelogService
-	TablesService    services.ITablesService
-	AuthService      services.IAuthService
-	DatabasesService services.IDatabasesService
-	FileService      services.IFileService
+	UsersService         services.IUsersService
+	ChangelogService     services.IChangelogService
+	TablesService        services.ITablesService
+	AuthService          services.IAuthService
+	DatabasesService     services.IDatabasesService
+	FileService          services.IFileService
+	Mailer               services.IMailer
+	InvitationsService   services.IInvitationsService
+	ShareLinksService    services.IShareLinksService
+	OrganizationsService services.IOrganizationsService
 }
 
 func NewServices(repos *repositories.Repositories, res *resources.Resources) *Services {
 	s := &Services{}
 
 	s.FileService = file_service.NewService()
-	s.UsersService = users.NewService(repos.UsersRepository)
+	s.Mailer = newMailer()
 	s.ChangelogService = changelog.NewService(repos.ChangelogRepository)
-	s.TablesService = tables.NewService(res.PostgresExecutor, repos.TablesRepository, s.ChangelogService, s.FileService)
-	s.AuthService = auth.NewService(s.UsersService)
-	s.DatabasesService = databases.NewService(repos.DatabasesRepository)
+	s.DatabasesService = databases.NewService(
+		res.PostgresExecutor,
+		repos.DatabasesRepository,
+		repos.UsersTablesRepository,
+		repos.DatabaseRolesRepository,
+		repos.OrganizationsRepository,
+		s.ChangelogService,
+	)
+	s.OrganizationsService = organizations.NewService(
+		res.PostgresExecutor,
+		repos.OrganizationsRepository,
+		repos.DatabasesRepository,
+		s.ChangelogService,
+	)
+	s.UsersService = users.NewService(
+		res.PostgresExecutor,
+		repos.UsersRepository,
+		repos.UserTokensRepository,
+		repos.UserIdentitiesRepository,
+		repos.SessionsRepository,
+		repos.PersonalAccessTokensRepository,
+		s.DatabasesService,
+		s.OrganizationsService,
+		s.ChangelogService,
+		res.PasswordHasher,
+		s.Mailer,
+	)
+	s.TablesService = tables.NewService(res.PostgresExecutor, repos.TablesRepository, repos.UsersTablesRepository, s.ChangelogService, s.FileService)
+	s.AuthService = auth.NewService(
+		s.UsersService,
+		repos.SessionsRepository,
+		repos.PersonalAccessTokensRepository,
+		res.RateLimiter,
+		res.OIDCProvider,
+		repos.OIDCLoginRequestsRepository,
+	)
+	s.InvitationsService = invitations.NewService(
+		res.PostgresExecutor,
+		repos.DatabaseInvitationsRepository,
+		s.UsersService,
+		s.DatabasesService,
+		s.ChangelogService,
+		s.Mailer,
+	)
+	s.ShareLinksService = share_links.NewService(repos.TableShareLinksRepository, s.TablesService, res.PasswordHasher)
 
 	return s
 }
+
+func newMailer() services.IMailer {
+	if os.Getenv("MAILER") != "smtp" {
+		return mailer.NewLogMailer(os.Getenv("MAIL_OUTPUT_DIR"))
+	}
+
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("MAIL_FROM"),
+	})
+}
@@ -1,12 +1,13 @@
 package entities
 
 const (
-	EventActionSetCellValue    string = "set_cell_value"
-	EventActionFetchTable      string = "fetch_table"
-	EventActionGoAwayFromTable string = "go_away_from_table"
-	EventActionFetchDatabases  string = "fetch_databases"
-	EventActionSetCellBusy     string = "set_cell_busy"
-	EventActionSetCellFree     string = "set_cell_free"
+	EventActionSetCellValue     string = "set_cell_value"
+	EventActionFetchTable       string = "fetch_table"
+	EventActionGoAwayFromTable  string = "go_away_from_table"
+	EventActionFetchDatabases   string = "fetch_databases"
+	EventActionSetCellBusy      string = "set_cell_busy"
+	EventActionSetCellFree      string = "set_cell_free"
+	EventActionFetchInvitations string = "fetch_invitations"
 )
 
 type SetCellValueMessage struct {
@@ -20,17 +20,61 @@ const (
 	ChangedEntityCell   ChangedEntity = "cell"
 	ChangedEntityRow    ChangedEntity = "row"
 	ChangedEntityColumn ChangedEntity = "column"
+
+	ChangedEntityDatabase ChangedEntity = "database"
+	ChangedEntityMember   ChangedEntity = "member"
+	ChangedEntityTable    ChangedEntity = "table"
 )
 
 type ChangelogItem struct {
-	ChangeID  int64         `db:"change_id"`
-	Target    ChangeTarget  `db:"target"`
-	UserID    int64         `db:"user_id"`
-	TableID   *string       `db:"table_id"`
-	ColumnID  *string       `db:"column_id"`
-	RowID     *int64        `db:"row_id"`
-	Change    JSONB[Change] `db:"change"`
-	ChangedAt time.Time     `db:"changed_at"`
+	ChangeID int64        `db:"change_id"`
+	Target   ChangeTarget `db:"target"`
+	UserID   int64        `db:"user_id"`
+	// DatabaseID is only set for the items targeting the database.
+	DatabaseID *int64        `db:"database_id"`
+	TableID    *string       `db:"table_id"`
+	ColumnID   *string       `db:"column_id"`
+	RowID      *int64        `db:"row_id"`
+	Change     JSONB[Change] `db:"change"`
+	ChangedAt  time.Time     `db:"changed_at"`
+}
+
+// CopyFor returns the item as written for a copy of its table, with the column IDs replaced
+// by the mapping. Items of columns that were not copied are dropped, so are column policies,
+// as copies do not keep them.
+func (i *ChangelogItem) CopyFor(tableID string, columnIDs map[string]string) (*ChangelogItem, bool) {
+	item := *i
+	item.ChangeID = 0
+	item.TableID = pointer.To(tableID)
+
+	if i.ColumnID != nil {
+		columnID, ok := columnIDs[*i.ColumnID]
+		if !ok {
+			return nil, false
+		}
+		item.ColumnID = pointer.To(columnID)
+	}
+
+	if change := i.Change.Get(); change != nil {
+		copiedChange := *change
+		if change.ColumnChange != nil {
+			copiedChange.ColumnChange = &ColumnChange{
+				ChangeType: change.ColumnChange.ChangeType,
+				Before:     change.ColumnChange.Before.copyFor(columnIDs),
+				After:      change.ColumnChange.After.copyFor(columnIDs),
+			}
+		}
+		if change.RowChange != nil {
+			copiedChange.RowChange = &RowChange{
+				ChangeType: change.RowChange.ChangeType,
+				Before:     change.RowChange.Before.copyFor(columnIDs),
+				After:      change.RowChange.After.copyFor(columnIDs),
+			}
+		}
+		item.Change = JSONB[Change]{v: &copiedChange}
+	}
+
+	return &item, true
 }
 
 type ChangelogItemWithUserInfo struct {
@@ -43,6 +87,10 @@ type Change struct {
 	CellChange    *CellChange   `json:"cell_change"`
 	ColumnChange  *ColumnChange `json:"column_change"`
 	RowChange     *RowChange    `json:"row_change"`
+
+	DatabaseChange *DatabaseChange `json:"database_change,omitempty"`
+	MemberChange   *MemberChange   `json:"member_change,omitempty"`
+	TableChange    *TableChange    `json:"table_change,omitempty"`
 }
 
 type CellChange struct {
@@ -56,6 +104,8 @@ const (
 	ChangeTypeAdd    ChangeType = "add"
 	ChangeTypeUpdate ChangeType = "update"
 	ChangeTypeDelete ChangeType = "delete"
+	// ChangeTypeRestore is only used by table changes.
+	ChangeTypeRestore ChangeType = "restore"
 )
 
 type ColumnChange struct {
@@ -112,6 +162,21 @@ func (i *RowChange) ToChangelogItem(
 
 type RowInfoForChangelog []*RowItemForChangelog
 
+func (r RowInfoForChangelog) copyFor(columnIDs map[string]string) RowInfoForChangelog {
+	if r == nil {
+		return nil
+	}
+	res := make(RowInfoForChangelog, 0, len(r))
+	for _, item := range r {
+		if columnID, ok := columnIDs[item.ColumnID]; ok {
+			copiedItem := *item
+			copiedItem.ColumnID = columnID
+			res = append(res, &copiedItem)
+		}
+	}
+	return res
+}
+
 type RowItemForChangelog struct {
 	ColumnID   string
 	ColumnName string
@@ -165,3 +230,84 @@ func (i *RawCellChangeInfo) ToChangelogItem(
 		ChangedAt: i.ChangedAt,
 	}
 }
+
+type DatabaseChange struct {
+	ChangeType ChangeType                `json:"change_type"`
+	Before     *DatabaseInfoForChangelog `json:"before"`
+	After      *DatabaseInfoForChangelog `json:"after"`
+}
+
+type DatabaseInfoForChangelog struct {
+	Name string `json:"name"`
+}
+
+func (i *DatabaseChange) ToChangelogItem(userID int64, databaseID int64) *ChangelogItem {
+	return newDatabaseChangelogItem(userID, databaseID, nil, &Change{
+		ChangedEntity:  ChangedEntityDatabase,
+		DatabaseChange: i,
+	})
+}
+
+// MemberChange records the role the member was granted, an empty role standing for no membership.
+type MemberChange struct {
+	ChangeType ChangeType `json:"change_type"`
+	MemberID   int64      `json:"member_id"`
+	Before     Role       `json:"before"`
+	After      Role       `json:"after"`
+}
+
+func NewMemberChange(memberID int64, before, after Role) *MemberChange {
+	changeType := ChangeTypeUpdate
+	switch {
+	case before == "":
+		changeType = ChangeTypeAdd
+	case after == "":
+		changeType = ChangeTypeDelete
+	}
+
+	return &MemberChange{
+		ChangeType: changeType,
+		MemberID:   memberID,
+		Before:     before,
+		After:      after,
+	}
+}
+
+func (i *MemberChange) ToChangelogItem(userID int64, databaseID int64) *ChangelogItem {
+	return newDatabaseChangelogItem(userID, databaseID, nil, &Change{
+		ChangedEntity: ChangedEntityMember,
+		MemberChange:  i,
+	})
+}
+
+type TableChange struct {
+	ChangeType ChangeType             `json:"change_type"`
+	Before     *TableInfoForChangelog `json:"before"`
+	After      *TableInfoForChangelog `json:"after"`
+}
+
+type TableInfoForChangelog struct {
+	Name string `json:"name"`
+}
+
+func NewTableInfoForChangelog(table *Table) *TableInfoForChangelog {
+	return &TableInfoForChangelog{Name: table.Name}
+}
+
+func (i *TableChange) ToChangelogItem(userID int64, databaseID int64, tableID string) *ChangelogItem {
+	return newDatabaseChangelogItem(userID, databaseID, pointer.To(tableID), &Change{
+		ChangedEntity: ChangedEntityTable,
+		TableChange:   i,
+	})
+}
+
+func newDatabaseChangelogItem(userID int64, databaseID int64, tableID *string, change *Change) *ChangelogItem {
+	return &ChangelogItem{
+		Target:     ChangeTargetDatabase,
+		UserID:     userID,
+		DatabaseID: pointer.To(databaseID),
+		TableID:    tableID,
+		Change:     JSONB[Change]{v: change},
+		ChangedAt:  time.Now(),
+	}
+}
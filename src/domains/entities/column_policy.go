@@ -0,0 +1,101 @@
+package entities
+
+import "slices"
+
+type ColumnAccess string
+
+const (
+	ColumnAccessHidden   ColumnAccess = "hidden"
+	ColumnAccessReadOnly ColumnAccess = "read_only"
+	// ColumnAccessFull lifts role policies for a single user.
+	ColumnAccessFull ColumnAccess = "full"
+)
+
+func (a ColumnAccess) restriction() int {
+	switch a {
+	case ColumnAccessHidden:
+		return 2
+	case ColumnAccessReadOnly:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ColumnPolicy restricts access to a column either for every member with the role, or for one user.
+type ColumnPolicy struct {
+	Role   Role         `json:"role,omitempty"`
+	UserID *int64       `json:"user_id,omitempty"`
+	Access ColumnAccess `json:"access"`
+}
+
+// TableViewer is the user a table is shown to, column policies are resolved against it.
+type TableViewer struct {
+	UserID int64
+	Role   Role
+}
+
+// AccessFor resolves the column policies for the viewer. A user policy takes precedence over
+// role policies, the most restrictive role policy wins otherwise. Admins are never restricted.
+func (c *TableColumn) AccessFor(viewer TableViewer) ColumnAccess {
+	if viewer.Role == RoleAdmin {
+		return ColumnAccessFull
+	}
+
+	access := ColumnAccessFull
+	for _, policy := range c.Policies {
+		if policy.UserID != nil {
+			if *policy.UserID == viewer.UserID {
+				return policy.Access
+			}
+			continue
+		}
+		if policy.Role == viewer.Role && policy.Access.restriction() > access.restriction() {
+			access = policy.Access
+		}
+	}
+	return access
+}
+
+// ForViewer returns a copy of the table without the columns hidden from the viewer
+// and with read-only and computed columns marked, so hidden values are never selected or sent.
+// Rows of the copy are limited by the row policies, see RowFilter. The copy must not be saved back.
+func (t *Table) ForViewer(viewer TableViewer) *Table {
+	view := *t
+	view.viewer = &viewer
+	view.Columns = make([]*TableColumn, 0, len(t.Columns))
+	for _, col := range t.Columns {
+		access := col.AccessFor(viewer)
+		if access == ColumnAccessHidden {
+			continue
+		}
+
+		viewColumn := *col
+		viewColumn.ReadOnly = access == ColumnAccessReadOnly || col.IsComputed()
+		view.Columns = append(view.Columns, &viewColumn)
+	}
+	return &view
+}
+
+// DropMemberPolicies removes the column and row policies of users and custom roles,
+// which only have a meaning in the database of the table. Built-in role policies are kept.
+func (t *Table) DropMemberPolicies() {
+	for _, col := range t.Columns {
+		col.Policies = slices.DeleteFunc(col.Policies, func(policy *ColumnPolicy) bool {
+			return policy.UserID != nil || !policy.Role.IsBuiltin()
+		})
+	}
+	t.RowPolicies = slices.DeleteFunc(t.RowPolicies, func(policy *RowPolicy) bool {
+		return policy.UserID != nil || !policy.Role.IsBuiltin()
+	})
+}
+
+// HasColumn reports whether the column, deleted or not, is part of the table.
+func (t *Table) HasColumn(columnID string) bool {
+	for _, col := range t.Columns {
+		if col.ID == columnID {
+			return true
+		}
+	}
+	return false
+}
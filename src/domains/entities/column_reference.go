@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColumnLink configures a link column. Its cells store the IDs of rows of another table
+// of the same database, separated by commas, and are read as the display values of these rows.
+type ColumnLink struct {
+	TableID         string `json:"table_id"`
+	DisplayColumnID string `json:"display_column_id"`
+}
+
+// ColumnLookup configures a lookup column, which shows a column of the rows linked by a link column.
+type ColumnLookup struct {
+	LinkColumnID string `json:"link_column_id"`
+	ColumnID     string `json:"column_id"`
+}
+
+type RollupFunction string
+
+const (
+	RollupFunctionCount RollupFunction = "count"
+	RollupFunctionSum   RollupFunction = "sum"
+	RollupFunctionMin   RollupFunction = "min"
+	RollupFunctionMax   RollupFunction = "max"
+)
+
+// ColumnRollup configures a rollup column, which aggregates a column of the rows linked by a link column.
+// Count needs no column, as it counts the linked rows.
+type ColumnRollup struct {
+	LinkColumnID string         `json:"link_column_id"`
+	ColumnID     string         `json:"column_id,omitempty"`
+	Function     RollupFunction `json:"function"`
+}
+
+// LinkedRecord is a linked row as returned in link and lookup cells.
+type LinkedRecord struct {
+	ID    int64   `json:"id"`
+	Value *string `json:"value"`
+}
+
+// LinkedTableView returns the linked table as shown to the reader of the linking table,
+// or nil when the reader may not see it. Linked rows are resolved through the view,
+// so its column and row policies apply.
+type LinkedTableView func(ctx context.Context, table *Table) (*Table, error)
+
+// IsReference reports whether the column shows the rows of a linked table.
+// Reference columns cannot be filtered or sorted by, as their cells are resolved after reading.
+func (c *TableColumn) IsReference() bool {
+	switch c.Type {
+	case ColumnTypeLink, ColumnTypeLookup, ColumnTypeRollup:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsComputed reports whether the column values are computed on read, such columns are never written.
+func (c *TableColumn) IsComputed() bool {
+	return c.Type == ColumnTypeLookup || c.Type == ColumnTypeRollup || c.Type == ColumnTypeFormula || c.IsSystem()
+}
+
+// LinkColumnID returns the link column a lookup or rollup column goes through.
+func (c *TableColumn) LinkColumnID() string {
+	switch {
+	case c.Type == ColumnTypeLookup && c.Lookup != nil:
+		return c.Lookup.LinkColumnID
+	case c.Type == ColumnTypeRollup && c.Rollup != nil:
+		return c.Rollup.LinkColumnID
+	default:
+		return ""
+	}
+}
+
+// LinkedColumnID returns the column of the linked table a lookup or rollup column shows.
+func (c *TableColumn) LinkedColumnID() string {
+	switch {
+	case c.Type == ColumnTypeLookup && c.Lookup != nil:
+		return c.Lookup.ColumnID
+	case c.Type == ColumnTypeRollup && c.Rollup != nil:
+		return c.Rollup.ColumnID
+	default:
+		return ""
+	}
+}
+
+// ParseLinkedRowIDs parses the value of a link cell, an empty value links no rows.
+func ParseLinkedRowIDs(value string) ([]int64, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	ids := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if id < 1 {
+			return nil, fmt.Errorf("invalid row id %d", id)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Aggregate applies the rollup function to the values of the linked rows, empty values are skipped.
+// Numeric columns are compared as numbers by min and max, other columns as text.
+func (r *ColumnRollup) Aggregate(column *TableColumn, values []*string) *string {
+	if r.Function == RollupFunctionCount {
+		return formatNumber(float64(len(values)))
+	}
+
+	numeric := column.Type == ColumnTypeNumeric || r.Function == RollupFunctionSum
+	var result *string
+	var sum, resultNumber float64
+	for _, value := range values {
+		if value == nil || *value == "" {
+			continue
+		}
+
+		if !numeric {
+			if result == nil || (r.Function == RollupFunctionMin) == (*value < *result) {
+				result = value
+			}
+			continue
+		}
+
+		number, err := strconv.ParseFloat(*value, 64)
+		if err != nil {
+			continue
+		}
+		sum += number
+		if result == nil || (r.Function == RollupFunctionMin) == (number < resultNumber) {
+			resultNumber = number
+			result = value
+		}
+	}
+
+	if r.Function == RollupFunctionSum {
+		return formatNumber(sum)
+	}
+	if result != nil && numeric {
+		return formatNumber(resultNumber)
+	}
+	return result
+}
+
+func formatNumber(n float64) *string {
+	s := strconv.FormatFloat(n, 'f', -1, 64)
+	return &s
+}
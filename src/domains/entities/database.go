@@ -3,10 +3,12 @@ package entities
 import "time"
 
 type Database struct {
-	ID        int64      `db:"id"`
-	Name      string     `db:"name"`
-	CreatedAt time.Time  `db:"created_at"`
-	DeletedAt *time.Time `db:"deleted_at"`
+	ID             int64      `db:"id"`
+	Name           string     `db:"name"`
+	OwnerID        *int64     `db:"owner_id"`
+	OrganizationID *int64     `db:"organization_id"`
+	CreatedAt      time.Time  `db:"created_at"`
+	DeletedAt      *time.Time `db:"deleted_at"`
 }
 
 type UsersDatabase struct {
@@ -16,27 +18,41 @@ type UsersDatabase struct {
 	CreatedAt  time.Time  `db:"created_at"`
 	DeletedAt  *time.Time `db:"deleted_at"`
 
-	Name string `db:"name"`
+	Name              string     `db:"name"`
+	OwnerID           *int64     `db:"owner_id"`
+	OrganizationID    *int64     `db:"organization_id"`
+	DatabaseDeletedAt *time.Time `db:"database_deleted_at"`
+}
+
+func (d *UsersDatabase) IsOwner() bool {
+	return d.OwnerID != nil && *d.OwnerID == d.UserID
 }
 
 type DatabasesUser struct {
 	*User
-	Role Role `db:"role"`
+	Role    Role `db:"role"`
+	IsOwner bool `db:"is_owner"`
 }
 
 type Role string
 
 const (
-	RoleReader Role = "reader"
-	RoleWriter Role = "writer"
-	RoleAdmin  Role = "admin"
+	// RoleNone is only used by table overrides to hide a table from a member.
+	RoleNone      Role = "none"
+	RoleReader    Role = "reader"
+	RoleCommenter Role = "commenter"
+	RoleWriter    Role = "writer"
+	RoleAdmin     Role = "admin"
 )
 
+// Priority orders the built-in roles, custom roles have none and are checked by permissions only.
 func (r Role) Priority() int {
 	switch r {
 	case RoleAdmin:
-		return 3
+		return 4
 	case RoleWriter:
+		return 3
+	case RoleCommenter:
 		return 2
 	case RoleReader:
 		return 1
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusDeclined InvitationStatus = "declined"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+type DatabaseInvitation struct {
+	ID          int64            `db:"id"`
+	DatabaseID  int64            `db:"database_id"`
+	Email       string           `db:"email"`
+	Role        Role             `db:"role"`
+	InvitedBy   int64            `db:"invited_by"`
+	UserID      *int64           `db:"user_id"`
+	Status      InvitationStatus `db:"status"`
+	CreatedAt   time.Time        `db:"created_at"`
+	ExpiresAt   time.Time        `db:"expires_at"`
+	RespondedAt *time.Time       `db:"responded_at"`
+
+	DatabaseName string `db:"database_name"`
+	InviterName  string `db:"inviter_name"`
+}
+
+func (i *DatabaseInvitation) IsPending() bool {
+	return i.Status == InvitationStatusPending && i.ExpiresAt.After(time.Now())
+}
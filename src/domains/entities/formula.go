@@ -0,0 +1,222 @@
+package entities
+
+import (
+	"backend/src/modules/formula"
+	"fmt"
+)
+
+// ColumnFormula configures a formula column. The expression references columns by ID, e.g. {col_<uuid>},
+// so formulas survive renames, and is shown with column names, see Table.FormulaWithNames.
+type ColumnFormula struct {
+	Expression string       `json:"expression"`
+	ResultType formula.Type `json:"result_type"`
+}
+
+// ValueType returns the type the values of the column are shown as,
+// for formulas the type of the result and for system columns the type of the row field.
+func (c *TableColumn) ValueType() ColumnType {
+	switch c.Type {
+	case ColumnTypeCreatedAt, ColumnTypeUpdatedAt:
+		return ColumnTypeTimestamp
+	case ColumnTypeAutonumber, ColumnTypeCreatedBy, ColumnTypeUpdatedBy:
+		return ColumnTypeText
+	}
+	if c.Type != ColumnTypeFormula || c.Formula == nil {
+		return c.Type
+	}
+
+	switch c.Formula.ResultType {
+	case formula.TypeNumber:
+		return ColumnTypeNumeric
+	case formula.TypeBoolean:
+		return ColumnTypeBoolean
+	case formula.TypeDate:
+		return ColumnTypeDate
+	case formula.TypeTimestamp:
+		return ColumnTypeTimestamp
+	default:
+		return ColumnTypeText
+	}
+}
+
+// ParseFormula converts an expression referencing the columns of the table by name into a formula
+// referencing them by ID. The formula is type checked by CompileFormulas once it is part of the table.
+func (t *Table) ParseFormula(expression string) (*ColumnFormula, error) {
+	if _, err := formula.Parse(expression); err != nil {
+		return nil, err
+	}
+
+	expression, err := formula.ReplaceReferences(expression, func(name string) (string, error) {
+		var found *TableColumn
+		for _, col := range t.Columns {
+			if col.DeletedAt != nil || col.Name != name {
+				continue
+			}
+			if found != nil {
+				return "", fmt.Errorf("column name %s is ambiguous", name)
+			}
+			found = col
+		}
+		if found == nil {
+			return "", fmt.Errorf("column %s not found", name)
+		}
+		return found.ID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ColumnFormula{Expression: expression}, nil
+}
+
+// FormulaWithNames returns the expression of the formula column with the column names in the references.
+func (t *Table) FormulaWithNames(column *TableColumn) string {
+	if column.Formula == nil {
+		return ""
+	}
+
+	expression, err := formula.ReplaceReferences(column.Formula.Expression, func(id string) (string, error) {
+		if col := t.GetColumn(id); col != nil {
+			return col.Name, nil
+		}
+		return id, nil
+	})
+	if err != nil {
+		return column.Formula.Expression
+	}
+	return expression
+}
+
+// CompileFormulas type checks the formulas of the table and updates their result types.
+// It fails on references to missing columns and on formulas referencing each other in a cycle.
+func (t *Table) CompileFormulas() error {
+	for _, col := range t.Columns {
+		if col.DeletedAt != nil || col.Type != ColumnTypeFormula {
+			continue
+		}
+
+		compiled, err := t.compileFormula(col, map[string]bool{})
+		if err != nil {
+			return fmt.Errorf("formula of column %s: %w", col.Name, err)
+		}
+		col.Formula.ResultType = compiled.Type
+	}
+	return nil
+}
+
+// computedSQL returns the SQL expression computing the formula or system column. Formulas referencing
+// columns missing from a table view cannot be computed, so hidden values do not leak through them.
+func (t *Table) computedSQL(column *TableColumn) (*formula.Operand, bool) {
+	if column.IsSystem() {
+		return systemOperand(column), true
+	}
+	compiled, err := t.compileFormula(column, map[string]bool{})
+	if err != nil {
+		return nil, false
+	}
+	return compiled, true
+}
+
+// selectExpression returns what is selected for the column: the column itself, or the text of the computed value.
+func (t *Table) selectExpression(column *TableColumn) string {
+	if !column.isComputedInSQL() {
+		return column.ID
+	}
+	return fmt.Sprintf("%s as %s", t.textExpression(column), column.ID)
+}
+
+// textExpression returns the text the column is filtered and searched by.
+func (t *Table) textExpression(column *TableColumn) string {
+	if !column.isComputedInSQL() {
+		return column.ID
+	}
+
+	compiled, ok := t.computedSQL(column)
+	if !ok {
+		return "null::text"
+	}
+	return formula.TextSQL(compiled.SQL, compiled.Type)
+}
+
+// isComputedInSQL reports whether the column values are computed by the queries reading the table.
+func (c *TableColumn) isComputedInSQL() bool {
+	return c.Type == ColumnTypeFormula || c.IsSystem()
+}
+
+// RecomputesOnWrite reports whether writing a cell changes other cells of the row,
+// as it does with formulas and the last modified columns.
+func (t *Table) RecomputesOnWrite() bool {
+	for _, col := range t.Columns {
+		if col.DeletedAt != nil {
+			continue
+		}
+		if col.Type == ColumnTypeFormula || col.Type == ColumnTypeUpdatedAt || col.Type == ColumnTypeUpdatedBy {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Table) compileFormula(column *TableColumn, visiting map[string]bool) (*formula.Operand, error) {
+	if column.Formula == nil {
+		return nil, fmt.Errorf("formula is empty")
+	}
+	if visiting[column.ID] {
+		return nil, fmt.Errorf("circular reference to column %s", column.Name)
+	}
+	visiting[column.ID] = true
+	defer delete(visiting, column.ID)
+
+	parsed, err := formula.Parse(column.Formula.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, typ, err := parsed.Compile(func(id string) (*formula.Operand, error) {
+		col := t.GetColumn(id)
+		if col == nil {
+			for _, deleted := range t.Columns {
+				if deleted.ID == id {
+					return nil, fmt.Errorf("column %s is deleted", deleted.Name)
+				}
+			}
+			return nil, fmt.Errorf("column %s not found", id)
+		}
+		return t.formulaOperand(col, visiting)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &formula.Operand{SQL: sql, Type: typ}, nil
+}
+
+// formulaOperand returns the typed value of the column referenced by a formula, empty cells are NULL.
+func (t *Table) formulaOperand(column *TableColumn, visiting map[string]bool) (*formula.Operand, error) {
+	if column.IsSystem() {
+		return systemOperand(column), nil
+	}
+
+	value := fmt.Sprintf("nullif(%s, '')", column.ID)
+	switch column.Type {
+	case ColumnTypeFormula:
+		return t.compileFormula(column, visiting)
+	case ColumnTypeLink, ColumnTypeLookup, ColumnTypeRollup:
+		return nil, fmt.Errorf("column %s cannot be used in formulas", column.Name)
+	case ColumnTypeNumeric:
+		// cells written before numericPattern was enforced may hold numbers Postgres cannot read,
+		// those count as empty instead of failing the whole query
+		trimmed := fmt.Sprintf("btrim(%s)", column.ID)
+		return &formula.Operand{
+			SQL:  fmt.Sprintf("(CASE WHEN %s ~ '%s' THEN %s::numeric END)", trimmed, numericPattern, trimmed),
+			Type: formula.TypeNumber,
+		}, nil
+	case ColumnTypeBoolean:
+		return &formula.Operand{SQL: value + "::boolean", Type: formula.TypeBoolean}, nil
+	case ColumnTypeDate:
+		return &formula.Operand{SQL: value + "::date", Type: formula.TypeDate}, nil
+	case ColumnTypeTimestamp:
+		return &formula.Operand{SQL: value + "::timestamptz", Type: formula.TypeTimestamp}, nil
+	default:
+		return &formula.Operand{SQL: value, Type: formula.TypeText}, nil
+	}
+}
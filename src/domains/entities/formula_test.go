@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"backend/src/modules/formula"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+func formulaColumn(id, expression string) *TableColumn {
+	return &TableColumn{ID: id, Name: strings.ToUpper(id), Type: ColumnTypeFormula, Formula: &ColumnFormula{Expression: expression}}
+}
+
+func TestCompileFormulas(t *testing.T) {
+	deletedAt := time.Now()
+	price := &TableColumn{ID: "price", Name: "Price", Type: ColumnTypeNumeric}
+	removed := &TableColumn{ID: "removed", Name: "Removed", Type: ColumnTypeText, DeletedAt: &deletedAt}
+
+	tests := []struct {
+		name    string
+		columns []*TableColumn
+		types   map[string]formula.Type
+		err     string
+	}{
+		{
+			name:    "chain of formulas",
+			columns: []*TableColumn{price, formulaColumn("a", "{price} * 2"), formulaColumn("b", "{a} & \" EUR\"")},
+			types:   map[string]formula.Type{"a": formula.TypeNumber, "b": formula.TypeText},
+		},
+		{
+			name:    "self reference",
+			columns: []*TableColumn{formulaColumn("a", "{a} + 1")},
+			err:     "formula of column A: circular reference to column A at position 1",
+		},
+		{
+			name:    "cycle through another formula",
+			columns: []*TableColumn{price, formulaColumn("a", "{price} + {b}"), formulaColumn("b", "{a} * 2")},
+			err:     "formula of column A: circular reference to column A at position 11",
+		},
+		{
+			name:    "cycle not including the compiled formula",
+			columns: []*TableColumn{formulaColumn("a", "{b}"), formulaColumn("b", "{c}"), formulaColumn("c", "{b}")},
+			err:     "formula of column A: circular reference to column B at position 1",
+		},
+		{
+			name:    "deleted column",
+			columns: []*TableColumn{removed, formulaColumn("a", "1 & {removed}")},
+			err:     "formula of column A: column Removed is deleted at position 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &Table{Columns: tt.columns}
+			err := table.CompileFormulas()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompileFormulas: %v", err)
+			}
+			for id, typ := range tt.types {
+				if got := table.GetColumn(id).Formula.ResultType; got != typ {
+					t.Errorf("column %s: result type %s, want %s", id, got, typ)
+				}
+			}
+		})
+	}
+}
+
+// TestQuestionMarksInComputedColumns checks question marks of formulas and autonumber prefixes
+// do not shift the placeholders of the queries reading the table.
+func TestQuestionMarksInComputedColumns(t *testing.T) {
+	price := &TableColumn{ID: "price", Name: "Price", Type: ColumnTypeNumeric}
+	label := formulaColumn("label", `{price} & "?"`)
+	number := &TableColumn{ID: "number", Name: "Number", Type: ColumnTypeAutonumber, Autonumber: &ColumnAutonumber{Prefix: "Q?-"}}
+	table := &Table{ID: "t", Columns: []*TableColumn{price, label, number}}
+	if err := table.CompileFormulas(); err != nil {
+		t.Fatalf("CompileFormulas: %v", err)
+	}
+
+	for _, filterBy := range []string{"label", "number"} {
+		t.Run(filterBy, func(t *testing.T) {
+			filterValue := "x"
+			_, filter, value := ReadTableParams{FilterBy: &filterBy, FilterValue: &filterValue}.GetFilter(table)
+			sql, args, err := sqrl.Select(table.ReturningCols()...).
+				From("t").
+				Where(sqrl.Expr(filter, value)).
+				PlaceholderFormat(sqrl.Dollar).
+				ToSql()
+			if err != nil {
+				t.Fatalf("ToSql: %v", err)
+			}
+
+			if len(args) != 1 || strings.Contains(sql, "?") || strings.Contains(sql, "$2") || !strings.Contains(sql, "ILIKE ANY ($1)") {
+				t.Fatalf("placeholders shifted in %s with %d args", sql, len(args))
+			}
+		})
+	}
+}
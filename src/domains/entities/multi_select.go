@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiSelectSeparator separates the options selected in a multi-select cell, e.g. "backend, urgent".
+// Spaces around the options are ignored, so exported cells are parsed back as they are.
+const MultiSelectSeparator = ","
+
+type FilterMode string
+
+const (
+	// FilterModeContainsAny matches multi-select cells with any of the filtered options selected.
+	FilterModeContainsAny FilterMode = "contains_any"
+	// FilterModeContainsAll matches multi-select cells with all the filtered options selected.
+	FilterModeContainsAll FilterMode = "contains_all"
+)
+
+// ParseMultiSelect splits the value of a multi-select cell into the selected options.
+func ParseMultiSelect(value string) []string {
+	options := make([]string, 0)
+	for _, option := range strings.Split(value, MultiSelectSeparator) {
+		if option = strings.TrimSpace(option); option != "" {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// FormatMultiSelect joins the selected options into the text of a multi-select cell.
+func FormatMultiSelect(options []string) string {
+	return strings.Join(options, MultiSelectSeparator+" ")
+}
+
+// multiSelectArraySQL returns the options selected in a multi-select cell as a text array.
+func multiSelectArraySQL(columnID string) string {
+	return fmt.Sprintf(`string_to_array(regexp_replace(btrim(coalesce(%s, '')), '\s*%s\s*', '%s', 'g'), '%s')`,
+		columnID, MultiSelectSeparator, MultiSelectSeparator, MultiSelectSeparator)
+}
@@ -0,0 +1,47 @@
+package entities
+
+import "time"
+
+// Organization groups databases, its admins implicitly administer all of them.
+type Organization struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type UsersOrganization struct {
+	UserID         int64            `db:"user_id"`
+	OrganizationID int64            `db:"organization_id"`
+	Role           OrganizationRole `db:"role"`
+	CreatedAt      time.Time        `db:"created_at"`
+	UpdatedAt      time.Time        `db:"updated_at"`
+
+	Name string `db:"name"`
+}
+
+type OrganizationsUser struct {
+	*User
+	Role OrganizationRole `db:"role"`
+}
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleMember OrganizationRole = "member"
+	OrganizationRoleAdmin  OrganizationRole = "admin"
+)
+
+func (r OrganizationRole) Priority() int {
+	switch r {
+	case OrganizationRoleAdmin:
+		return 2
+	case OrganizationRoleMember:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (r OrganizationRole) Authorize(role OrganizationRole) bool {
+	return r.Priority() >= role.Priority()
+}
@@ -0,0 +1,105 @@
+package entities
+
+import "time"
+
+// Permission is a single action a role allows in a database.
+type Permission string
+
+const (
+	PermissionRead          Permission = "read"
+	PermissionComment       Permission = "comment"
+	PermissionWriteCells    Permission = "write_cells"
+	PermissionAddRows       Permission = "add_rows"
+	PermissionDeleteRows    Permission = "delete_rows"
+	PermissionEditSchema    Permission = "edit_schema"
+	PermissionManageMembers Permission = "manage_members"
+	PermissionExport        Permission = "export"
+)
+
+var AllPermissions = Permissions{
+	PermissionRead,
+	PermissionComment,
+	PermissionWriteCells,
+	PermissionAddRows,
+	PermissionDeleteRows,
+	PermissionEditSchema,
+	PermissionManageMembers,
+	PermissionExport,
+}
+
+type Permissions []Permission
+
+func (p Permissions) Has(permission Permission) bool {
+	for _, v := range p {
+		if v == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether every permission of other is in p.
+func (p Permissions) Contains(other Permissions) bool {
+	for _, v := range other {
+		if !p.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Permissions) Intersect(other Permissions) Permissions {
+	res := make(Permissions, 0, len(p))
+	for _, v := range p {
+		if other.Has(v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+var builtinRolePermissions = map[Role]Permissions{
+	RoleNone:      {},
+	RoleReader:    {PermissionRead, PermissionExport},
+	RoleCommenter: {PermissionRead, PermissionComment, PermissionExport},
+	RoleWriter: {
+		PermissionRead,
+		PermissionComment,
+		PermissionExport,
+		PermissionWriteCells,
+		PermissionAddRows,
+		PermissionDeleteRows,
+	},
+	RoleAdmin: AllPermissions,
+}
+
+// BuiltinRoles lists the roles every database has, in the order they are offered.
+var BuiltinRoles = []Role{RoleReader, RoleCommenter, RoleWriter, RoleAdmin}
+
+func (r Role) IsBuiltin() bool {
+	_, ok := builtinRolePermissions[r]
+	return ok
+}
+
+// BuiltinPermissions returns the permissions of a built-in role, custom roles are defined per database.
+func (r Role) BuiltinPermissions() (Permissions, bool) {
+	permissions, ok := builtinRolePermissions[r]
+	return permissions, ok
+}
+
+// DatabaseRole is a custom role defined in a database as a set of permissions.
+type DatabaseRole struct {
+	ID          int64              `db:"id"`
+	DatabaseID  int64              `db:"database_id"`
+	Name        Role               `db:"name"`
+	Permissions JSONB[Permissions] `db:"permissions"`
+	CreatedAt   time.Time          `db:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+}
+
+func (r *DatabaseRole) GetPermissions() Permissions {
+	if permissions := r.Permissions.Get(); permissions != nil {
+		return *permissions
+	}
+	return Permissions{}
+}
@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/elgris/sqrl"
+)
+
+type RowPredicateOperator string
+
+const (
+	RowPredicateOperatorEq  RowPredicateOperator = "eq"
+	RowPredicateOperatorNeq RowPredicateOperator = "neq"
+)
+
+// RowPredicate compares a cell of the row with a value, a nil value matches empty cells.
+type RowPredicate struct {
+	ColumnID string               `json:"column_id"`
+	Operator RowPredicateOperator `json:"operator"`
+	Value    *string              `json:"value"`
+}
+
+func (p *RowPredicate) condition() sqrl.Sqlizer {
+	if p.Operator == RowPredicateOperatorNeq {
+		return sqrl.Expr(fmt.Sprintf("%s IS DISTINCT FROM ?", p.ColumnID), p.Value)
+	}
+	return sqrl.Expr(fmt.Sprintf("%s IS NOT DISTINCT FROM ?", p.ColumnID), p.Value)
+}
+
+// RowPolicy limits the rows visible to every member with the role, or to one user.
+// A row passes the policy when it matches all the predicates or, with OwnRows, was created by the viewer.
+type RowPolicy struct {
+	Role       Role            `json:"role,omitempty"`
+	UserID     *int64          `json:"user_id,omitempty"`
+	Predicates []*RowPredicate `json:"predicates,omitempty"`
+	OwnRows    bool            `json:"own_rows"`
+}
+
+func (p *RowPolicy) condition(viewerID int64) sqrl.Sqlizer {
+	conds := sqrl.Or{}
+	if len(p.Predicates) > 0 {
+		predicates := make(sqrl.And, 0, len(p.Predicates))
+		for _, predicate := range p.Predicates {
+			predicates = append(predicates, predicate.condition())
+		}
+		conds = append(conds, predicates)
+	}
+	if p.OwnRows {
+		conds = append(conds, sqrl.Eq{"created_by": viewerID})
+	}
+	if len(conds) == 0 {
+		return sqrl.Expr("false")
+	}
+	return conds
+}
+
+// FiltersRowsBy reports whether a predicate of the row policies compares the column.
+func (t *Table) FiltersRowsBy(columnID string) bool {
+	for _, policy := range t.RowPolicies {
+		for _, predicate := range policy.Predicates {
+			if predicate.ColumnID == columnID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RowFilter returns the condition limiting the rows of a table view to the ones the viewer
+// may access, or nil when every row is accessible. User policies take precedence over role
+// policies, a row passing any of the applied policies is accessible. Admins are never restricted.
+func (t *Table) RowFilter() sqrl.Sqlizer {
+	if t.viewer == nil || t.viewer.Role == RoleAdmin {
+		return nil
+	}
+
+	userPolicies := make([]*RowPolicy, 0)
+	rolePolicies := make([]*RowPolicy, 0)
+	for _, policy := range t.RowPolicies {
+		if policy.UserID != nil {
+			if *policy.UserID == t.viewer.UserID {
+				userPolicies = append(userPolicies, policy)
+			}
+			continue
+		}
+		if policy.Role == t.viewer.Role {
+			rolePolicies = append(rolePolicies, policy)
+		}
+	}
+
+	policies := userPolicies
+	if len(policies) == 0 {
+		policies = rolePolicies
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	conds := make(sqrl.Or, 0, len(policies))
+	for _, policy := range policies {
+		conds = append(conds, policy.condition(t.viewer.UserID))
+	}
+	return conds
+}
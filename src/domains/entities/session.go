@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+type Session struct {
+	ID                       string     `db:"id"`
+	UserID                   int64      `db:"user_id"`
+	RefreshTokenHash         string     `db:"refresh_token_hash"`
+	PreviousRefreshTokenHash *string    `db:"previous_refresh_token_hash"`
+	UserAgent                string     `db:"user_agent"`
+	IP                       string     `db:"ip"`
+	CreatedAt                time.Time  `db:"created_at"`
+	LastUsedAt               time.Time  `db:"last_used_at"`
+	ExpiresAt                time.Time  `db:"expires_at"`
+	RevokedAt                *time.Time `db:"revoked_at"`
+}
+
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(time.Now())
+}
+
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+type AuthTokens struct {
+	AccessToken          string
+	AccessTokenExpiresAt time.Time
+	RefreshToken         string
+	SessionID            string
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"backend/src/modules/formula"
+	"fmt"
+)
+
+// usersTable is the table the names of the row authors are taken from.
+const usersTable = "app.users"
+
+// ColumnAutonumber configures an autonumber column, rows are numbered by their IDs, e.g. TASK-42.
+type ColumnAutonumber struct {
+	Prefix string `json:"prefix"`
+}
+
+// IsSystem reports whether the column shows the row fields maintained on writes
+// instead of a stored value: the row number, when and by whom the row was created and last modified.
+func (c *TableColumn) IsSystem() bool {
+	switch c.Type {
+	case ColumnTypeAutonumber, ColumnTypeCreatedAt, ColumnTypeCreatedBy, ColumnTypeUpdatedAt, ColumnTypeUpdatedBy:
+		return true
+	default:
+		return false
+	}
+}
+
+// systemOperand returns the typed value of a system column, the authors are shown by their names.
+func systemOperand(column *TableColumn) *formula.Operand {
+	switch column.Type {
+	case ColumnTypeAutonumber:
+		prefix := ""
+		if column.Autonumber != nil {
+			prefix = column.Autonumber.Prefix
+		}
+		return &formula.Operand{
+			SQL:  fmt.Sprintf("concat(%s, id)", formula.Quote(prefix)),
+			Type: formula.TypeText,
+		}
+	case ColumnTypeCreatedAt:
+		return &formula.Operand{SQL: "created_at", Type: formula.TypeTimestamp}
+	case ColumnTypeUpdatedAt:
+		return &formula.Operand{SQL: "updated_at", Type: formula.TypeTimestamp}
+	case ColumnTypeCreatedBy:
+		return &formula.Operand{SQL: userNameSQL("created_by"), Type: formula.TypeText}
+	default:
+		return &formula.Operand{SQL: userNameSQL("updated_by"), Type: formula.TypeText}
+	}
+}
+
+func userNameSQL(field string) string {
+	return fmt.Sprintf("(select u.name from %s as u where u.id = %s)", usersTable, field)
+}
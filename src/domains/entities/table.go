@@ -3,6 +3,7 @@ package entities
 import (
 	"backend/src/modules/sql_executor"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -15,11 +16,15 @@ const (
 )
 
 type Table struct {
-	ID         string
-	Name       string
-	DatabaseID int64
-	Columns    []*TableColumn
-	CreatedAt  time.Time
+	ID          string
+	Name        string
+	DatabaseID  int64
+	Columns     []*TableColumn
+	RowPolicies []*RowPolicy
+	CreatedAt   time.Time
+
+	// viewer is set on table views, see ForViewer
+	viewer *TableViewer
 }
 
 func (t *Table) ToDBTable() *DBTable {
@@ -27,19 +32,25 @@ func (t *Table) ToDBTable() *DBTable {
 	if t.Columns != nil {
 		columns = t.Columns
 	}
+	rowPolicies := make([]*RowPolicy, 0)
+	if t.RowPolicies != nil {
+		rowPolicies = t.RowPolicies
+	}
 	return &DBTable{
-		ID:         t.ID,
-		Name:       t.Name,
-		DatabaseID: t.DatabaseID,
-		Columns:    JSONB[[]*TableColumn]{v: &columns},
-		CreatedAt:  t.CreatedAt,
+		ID:          t.ID,
+		Name:        t.Name,
+		DatabaseID:  t.DatabaseID,
+		Columns:     JSONB[[]*TableColumn]{v: &columns},
+		RowPolicies: JSONB[[]*RowPolicy]{v: &rowPolicies},
+		CreatedAt:   t.CreatedAt,
 	}
 }
 
 func (t *Table) CreateExpression() sql_executor.IToSQL {
 	builder := strings.Builder{}
 	builder.WriteString(fmt.Sprintf("CREATE TABLE %s.%s ( ", UsersTablespace, t.ID))
-	builder.WriteString("id bigserial primary key, sort_index bigserial not null, sort_index_version bigint not null default 0, ")
+	builder.WriteString("id bigserial primary key, sort_index bigserial not null, sort_index_version bigint not null default 0, created_by integer, ")
+	builder.WriteString("created_at timestamp with time zone not null default now(), updated_at timestamp with time zone not null default now(), updated_by integer, ")
 
 	for _, column := range t.Columns {
 		builder.WriteString(fmt.Sprintf("%s text, ", column.ID))
@@ -70,12 +81,22 @@ func (t *Table) ReturningCols() []string {
 		if col.DeletedAt != nil {
 			continue
 		}
-		returningCols = append(returningCols, col.ID)
+		returningCols = append(returningCols, t.selectExpression(col))
 	}
 
 	return returningCols
 }
 
+// GetColumn returns the column of the table unless it is deleted.
+func (t *Table) GetColumn(columnID string) *TableColumn {
+	for _, col := range t.Columns {
+		if col.ID == columnID && col.DeletedAt == nil {
+			return col
+		}
+	}
+	return nil
+}
+
 func (t *Table) ValidateParams(params *ReadTableParams) bool {
 	return t.ValidateFilter(params) && t.ValidateSort(params)
 }
@@ -90,7 +111,10 @@ func (t *Table) ValidateFilter(params *ReadTableParams) bool {
 			continue
 		}
 		if col.ID == *params.FilterBy {
-			return true
+			if params.FilterMode != nil {
+				return col.Type == ColumnTypeMultiSelect
+			}
+			return !col.IsReference()
 		}
 	}
 
@@ -107,7 +131,7 @@ func (t *Table) ValidateSort(params *ReadTableParams) bool {
 			continue
 		}
 		if col.ID == *params.SortBy {
-			return true
+			return !col.IsReference()
 		}
 	}
 
@@ -115,29 +139,54 @@ func (t *Table) ValidateSort(params *ReadTableParams) bool {
 }
 
 type DBTable struct {
-	Name       string                `db:"name"`
-	ID         string                `db:"id"`
-	DatabaseID int64                 `db:"database_id"`
-	Columns    JSONB[[]*TableColumn] `db:"columns"`
-	CreatedAt  time.Time             `db:"created_at"`
+	Name        string                `db:"name"`
+	ID          string                `db:"id"`
+	DatabaseID  int64                 `db:"database_id"`
+	Columns     JSONB[[]*TableColumn] `db:"columns"`
+	RowPolicies JSONB[[]*RowPolicy]   `db:"row_policies"`
+	CreatedAt   time.Time             `db:"created_at"`
 }
 
 func (t *DBTable) ToTable() *Table {
+	var rowPolicies []*RowPolicy
+	if t.RowPolicies.Get() != nil {
+		rowPolicies = *t.RowPolicies.Get()
+	}
 	return &Table{
-		Name:       t.Name,
-		ID:         t.ID,
-		DatabaseID: t.DatabaseID,
-		Columns:    *t.Columns.Get(),
-		CreatedAt:  t.CreatedAt,
+		Name:        t.Name,
+		ID:          t.ID,
+		DatabaseID:  t.DatabaseID,
+		Columns:     *t.Columns.Get(),
+		RowPolicies: rowPolicies,
+		CreatedAt:   t.CreatedAt,
 	}
 }
 
 type TableColumn struct {
-	Name      string     `json:"name"`
-	Type      ColumnType `json:"type"`
-	Enum      []string   `json:"enum,omitempty"`
-	ID        string     `json:"id"`
-	DeletedAt *time.Time `json:"deleted_at"`
+	Name       string            `json:"name"`
+	Type       ColumnType        `json:"type"`
+	Enum       []string          `json:"enum,omitempty"`
+	ID         string            `json:"id"`
+	Policies   []*ColumnPolicy   `json:"policies,omitempty"`
+	Link       *ColumnLink       `json:"link,omitempty"`
+	Lookup     *ColumnLookup     `json:"lookup,omitempty"`
+	Rollup     *ColumnRollup     `json:"rollup,omitempty"`
+	Formula    *ColumnFormula    `json:"formula,omitempty"`
+	Autonumber *ColumnAutonumber `json:"autonumber,omitempty"`
+	DeletedAt  *time.Time        `json:"deleted_at"`
+
+	// ReadOnly is set on columns of a table view, see Table.ForViewer.
+	ReadOnly bool `json:"-"`
+}
+
+func (c *TableColumn) copyFor(columnIDs map[string]string) *TableColumn {
+	if c == nil {
+		return nil
+	}
+	column := *c
+	column.ID = columnIDs[c.ID]
+	column.Policies = nil
+	return &column
 }
 
 func (c *TableColumn) NeedToBeUpdated(new *TableColumn) bool {
@@ -145,6 +194,23 @@ func (c *TableColumn) NeedToBeUpdated(new *TableColumn) bool {
 		return true
 	}
 
+	if !reflect.DeepEqual(c.Policies, new.Policies) {
+		return true
+	}
+
+	if !reflect.DeepEqual(c.Link, new.Link) || !reflect.DeepEqual(c.Lookup, new.Lookup) || !reflect.DeepEqual(c.Rollup, new.Rollup) {
+		return true
+	}
+
+	if !reflect.DeepEqual(c.Autonumber, new.Autonumber) {
+		return true
+	}
+
+	// the result type is derived from the expression, so only the expressions are compared
+	if (c.Formula == nil) != (new.Formula == nil) || (c.Formula != nil && c.Formula.Expression != new.Formula.Expression) {
+		return true
+	}
+
 	newEnum := make(map[string]struct{})
 	for _, v := range new.Enum {
 		newEnum[v] = struct{}{}
@@ -170,12 +236,35 @@ const (
 	ColumnTypeNumeric   ColumnType = "numeric"
 	ColumnTypeEnum      ColumnType = "enum"
 	ColumnTypeTimestamp ColumnType = "timestamp"
+	ColumnTypeBoolean   ColumnType = "boolean"
+	ColumnTypeDate      ColumnType = "date"
+	ColumnTypeURL       ColumnType = "url"
+	ColumnTypeEmail     ColumnType = "email"
+	ColumnTypePhone     ColumnType = "phone"
+	ColumnTypeLink      ColumnType = "link"
+	ColumnTypeLookup    ColumnType = "lookup"
+	ColumnTypeRollup    ColumnType = "rollup"
+	ColumnTypeFormula   ColumnType = "formula"
+
+	// ColumnTypeMultiSelect cells hold several options of the enum, see ParseMultiSelect
+	ColumnTypeMultiSelect ColumnType = "multi_select"
+
+	// system columns, see TableColumn.IsSystem
+	ColumnTypeAutonumber ColumnType = "autonumber"
+	ColumnTypeCreatedAt  ColumnType = "created_at"
+	ColumnTypeCreatedBy  ColumnType = "created_by"
+	ColumnTypeUpdatedAt  ColumnType = "updated_at"
+	ColumnTypeUpdatedBy  ColumnType = "updated_by"
 )
 
 func (t ColumnType) TypeCast() string {
 	switch t {
 	case ColumnTypeNumeric:
 		return "::numeric"
+	case ColumnTypeBoolean:
+		return "::boolean"
+	case ColumnTypeDate:
+		return "::date"
 	default:
 		return ""
 	}
@@ -195,6 +284,9 @@ type ReadTableParams struct {
 	FilterBy    *string `form:"filterBy" binding:"omitempty,gt=0,excluded_without=FilterValue"`
 	FilterValue *string `form:"filterValue" binding:"omitempty,gt=0"`
 	SearchValue *string `form:"searchValue" binding:"omitempty,gt=0"`
+
+	// FilterMode filters multi-select columns by the options listed in FilterValue instead of by the text
+	FilterMode *FilterMode `form:"filterMode" binding:"omitempty,oneof=contains_any contains_all"`
 }
 
 func (p ReadTableParams) GetLimit() int {
@@ -218,20 +310,48 @@ func (p ReadTableParams) GetSortBy(t *Table) string {
 	var columnType ColumnType
 	for _, col := range t.Columns {
 		if col.ID == *p.SortBy {
+			if col.Type == ColumnTypeAutonumber {
+				return fmt.Sprintf("id %s", sortDir)
+			}
+			if col.isComputedInSQL() {
+				// computed values are sorted by their typed values, not by the text they are returned as
+				compiled, ok := t.computedSQL(col)
+				if !ok {
+					return ""
+				}
+				return fmt.Sprintf("%s %s", compiled.SQL, sortDir)
+			}
 			columnType = col.Type
 			break
 		}
 	}
 
-	return fmt.Sprintf("%s%s %s", *p.SortBy, columnType.TypeCast(), sortDir)
+	if typeCast := columnType.TypeCast(); typeCast != "" {
+		// empty values can't be cast, so they are sorted as nulls
+		return fmt.Sprintf("nullif(%s, '')%s %s", *p.SortBy, typeCast, sortDir)
+	}
+	return fmt.Sprintf("%s %s", *p.SortBy, sortDir)
 }
 
-func (p ReadTableParams) GetFilter() (bool, string, interface{}) {
+func (p ReadTableParams) GetFilter(t *Table) (bool, string, interface{}) {
 	if p.FilterBy == nil {
 		return false, "", nil
 	}
 
-	filterSql, filterValue := LikeFilter(*p.FilterValue, *p.FilterBy)
+	field := *p.FilterBy
+	if col := t.GetColumn(field); col != nil {
+		if p.FilterMode != nil && col.Type == ColumnTypeMultiSelect {
+			operator := "&&"
+			if *p.FilterMode == FilterModeContainsAll {
+				operator = "@>"
+			}
+			filterSql := fmt.Sprintf("%s %s ?", multiSelectArraySQL(col.ID), operator)
+			return true, filterSql, pg.Array(ParseMultiSelect(*p.FilterValue))
+		}
+		field = t.textExpression(col)
+	}
+
+	filterSql, filterValue := LikeFilter(*p.FilterValue, field)
 	return true, filterSql, filterValue
 }
 
@@ -242,13 +362,13 @@ func (p ReadTableParams) GetSearch(t *Table) sqrl.Or {
 
 	conds := sqrl.Or{}
 	for _, col := range t.Columns {
-		if col.DeletedAt != nil {
+		if col.DeletedAt != nil || col.IsReference() {
 			continue
 		}
 
 		conds = sqrl.Or{
 			conds,
-			sqrl.Expr(LikeFilter(*p.SearchValue, col.ID)),
+			sqrl.Expr(LikeFilter(*p.SearchValue, t.textExpression(col))),
 		}
 	}
 
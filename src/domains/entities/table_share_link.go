@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+// TableShareLink gives read-only access to a table to anyone knowing its token.
+type TableShareLink struct {
+	ID           int64           `db:"id"`
+	TableID      string          `db:"table_id"`
+	CreatedBy    int64           `db:"created_by"`
+	TokenHash    string          `db:"token_hash"`
+	TokenPrefix  string          `db:"token_prefix"`
+	PasswordHash *string         `db:"password_hash"`
+	ColumnIDs    JSONB[[]string] `db:"column_ids"`
+	Live         bool            `db:"live"`
+	CreatedAt    time.Time       `db:"created_at"`
+	ExpiresAt    *time.Time      `db:"expires_at"`
+	RevokedAt    *time.Time      `db:"revoked_at"`
+}
+
+func (l *TableShareLink) IsActive() bool {
+	return l.RevokedAt == nil && (l.ExpiresAt == nil || l.ExpiresAt.After(time.Now()))
+}
+
+func (l *TableShareLink) HasPassword() bool {
+	return l.PasswordHash != nil
+}
+
+// WSClientID identifies the link's connections in a hub, as anonymous clients have no user ID.
+// It is negative, so it cannot collide with user IDs.
+func (l *TableShareLink) WSClientID() int64 {
+	return -l.ID
+}
+
+// SharedLinkedTableView shows the tables linked by a shared table the way a reader sees them, as the shared table itself.
+func SharedLinkedTableView(_ context.Context, table *Table) (*Table, error) {
+	return table.ForViewer(TableViewer{Role: RoleReader}), nil
+}
+
+// Apply returns the table as shown through the link: what a reader would see, limited to the link's columns.
+func (l *TableShareLink) Apply(table *Table) *Table {
+	view := table.ForViewer(TableViewer{Role: RoleReader})
+
+	columnIDs := l.ColumnIDs.Get()
+	if columnIDs == nil || len(*columnIDs) == 0 {
+		return view
+	}
+
+	shared := make(map[string]struct{}, len(*columnIDs))
+	for _, id := range *columnIDs {
+		shared[id] = struct{}{}
+	}
+
+	columns := make([]*TableColumn, 0, len(shared))
+	for _, col := range view.Columns {
+		if _, ok := shared[col.ID]; ok {
+			columns = append(columns, col)
+		}
+	}
+	view.Columns = columns
+	return view
+}
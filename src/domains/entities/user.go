@@ -3,9 +3,24 @@ package entities
 import "time"
 
 type User struct {
-	ID        int64     `db:"id" json:"id"`
-	Name      string    `db:"name" json:"name"`
-	Email     string    `db:"email" json:"email"`
-	Password  string    `db:"password" json:"-"`
-	CreatedAt time.Time `db:"created_at" json:"-"`
+	ID              int64      `db:"id" json:"id"`
+	Name            string     `db:"name" json:"name"`
+	Email           string     `db:"email" json:"email"`
+	Password        string     `db:"password" json:"-"`
+	CreatedAt       time.Time  `db:"created_at" json:"-"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at" json:"-"`
+}
+
+type SearchUsersParams struct {
+	Query   string `form:"query"`
+	Page    int    `form:"page" binding:"required,min=1"`
+	PerPage int    `form:"perPage" binding:"required,min=1,max=100"`
+}
+
+func (p SearchUsersParams) GetLimit() int {
+	return p.PerPage
+}
+
+func (p SearchUsersParams) GetOffset() int {
+	return (p.Page - 1) * p.PerPage
 }
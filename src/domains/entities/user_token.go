@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+type UserTokenPurpose string
+
+const (
+	UserTokenPurposePasswordReset     UserTokenPurpose = "password_reset"
+	UserTokenPurposeEmailVerification UserTokenPurpose = "email_verification"
+	UserTokenPurposeEmailChange       UserTokenPurpose = "email_change"
+)
+
+// UserToken is a single-use secret sent to the user by email.
+type UserToken struct {
+	ID        int64            `db:"id"`
+	UserID    int64            `db:"user_id"`
+	Purpose   UserTokenPurpose `db:"purpose"`
+	TokenHash string           `db:"token_hash"`
+	Email     string           `db:"email"`
+	CreatedAt time.Time        `db:"created_at"`
+	ExpiresAt time.Time        `db:"expires_at"`
+	UsedAt    *time.Time       `db:"used_at"`
+}
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// UsersTable overrides the database role of a member for a single table.
+type UsersTable struct {
+	UserID    int64     `db:"user_id"`
+	TableID   string    `db:"table_id"`
+	Role      Role      `db:"role"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type TablesUser struct {
+	*User
+	Role Role `db:"role"`
+}
+
+// EffectiveTableRole applies the table override to the database role.
+// Overrides only exist for members, and never affect database admins.
+func EffectiveTableRole(databaseRole, tableRole Role) Role {
+	if databaseRole == "" || databaseRole == RoleAdmin || tableRole == "" {
+		return databaseRole
+	}
+	return tableRole
+}
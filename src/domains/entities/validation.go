@@ -1,11 +1,29 @@
 package entities
 
 import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/AlekSi/pointer"
 )
 
+const DateLayout = "2006-01-02"
+
+var phoneRegexp = regexp.MustCompile(`^\+?[0-9()\-.\s]+$`)
+
+// numericPattern matches the plain decimal numbers the Postgres numeric type reads, leaving out
+// the infinities, NaN and hex floats strconv.ParseFloat accepts too. It is written to work
+// both as a Go and as a Postgres regular expression, and spells ? as {0,1} since it is inlined
+// into queries where sqrl would read ? as a placeholder.
+const numericPattern = `^[+-]{0,1}([0-9]+[.]{0,1}[0-9]*|[.][0-9]+)([eE][+-]{0,1}[0-9]{1,3}){0,1}$`
+
+var numericRegexp = regexp.MustCompile(numericPattern)
+
 var timestampLayouts = []string{
 	time.RFC3339,
 	time.RFC3339Nano,
@@ -42,6 +60,19 @@ func TryParseTimestamp(s string) (time.Time, string, error) {
 	return zero, "", lastErr
 }
 
+// NormalizeColumnValue returns the valid value the way it is stored, i.e. booleans as true or false
+// whatever spelling strconv.ParseBool accepted, so that they are filtered and searched alike.
+func (c *TableColumn) NormalizeColumnValue(value *string) *string {
+	if value == nil || c.Type != ColumnTypeBoolean {
+		return value
+	}
+	b, err := strconv.ParseBool(*value)
+	if err != nil {
+		return value
+	}
+	return pointer.To(strconv.FormatBool(b))
+}
+
 func (c *TableColumn) ValidateColumnValue(value *string) bool {
 	if value == nil || *value == "" {
 		return true
@@ -51,8 +82,7 @@ func (c *TableColumn) ValidateColumnValue(value *string) bool {
 	case ColumnTypeText:
 		return true
 	case ColumnTypeNumeric:
-		_, err := strconv.ParseFloat(*value, 64)
-		return err == nil
+		return numericRegexp.MatchString(*value)
 	case ColumnTypeEnum:
 		for _, v := range c.Enum {
 			if v == *value {
@@ -60,10 +90,61 @@ func (c *TableColumn) ValidateColumnValue(value *string) bool {
 			}
 		}
 		return false
+	case ColumnTypeMultiSelect:
+		for _, option := range ParseMultiSelect(*value) {
+			if !slices.Contains(c.Enum, option) {
+				return false
+			}
+		}
+		return true
 	case ColumnTypeTimestamp:
 		_, _, err := TryParseTimestamp(*value)
 		return err == nil
+	case ColumnTypeBoolean:
+		_, err := strconv.ParseBool(*value)
+		return err == nil
+	case ColumnTypeDate:
+		_, err := time.Parse(DateLayout, *value)
+		return err == nil
+	case ColumnTypeURL:
+		return isValidURL(*value)
+	case ColumnTypeEmail:
+		address, err := mail.ParseAddress(*value)
+		return err == nil && address.Address == *value
+	case ColumnTypePhone:
+		return isValidPhone(*value)
+	case ColumnTypeLink:
+		_, err := ParseLinkedRowIDs(*value)
+		return err == nil
+	case ColumnTypeLookup, ColumnTypeRollup, ColumnTypeFormula,
+		ColumnTypeAutonumber, ColumnTypeCreatedAt, ColumnTypeCreatedBy, ColumnTypeUpdatedAt, ColumnTypeUpdatedBy:
+		// computed columns ignore what is stored, so a column keeps its values when turned into one
+		return true
 	default:
 		return false
 	}
 }
+
+func isValidURL(value string) bool {
+	u, err := url.ParseRequestURI(value)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// isValidPhone accepts the common phone notations, e.g. "+1 (555) 123-45-67",
+// with the digit count allowed by E.164.
+func isValidPhone(value string) bool {
+	if !phoneRegexp.MatchString(value) {
+		return false
+	}
+
+	digits := 0
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	return digits >= 7 && digits <= 15
+}
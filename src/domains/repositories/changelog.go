@@ -25,6 +25,7 @@ func (r *changelogRepository) AddChangelogItems(ctx context.Context, items []*en
 		Columns(
 			"target",
 			"user_id",
+			"database_id",
 			"table_id",
 			"column_id",
 			"row_id",
@@ -36,6 +37,7 @@ func (r *changelogRepository) AddChangelogItems(ctx context.Context, items []*en
 		q = q.Values(
 			item.Target,
 			item.UserID,
+			item.DatabaseID,
 			item.TableID,
 			item.ColumnID,
 			item.RowID,
@@ -91,3 +93,35 @@ func (r *changelogRepository) ListChangelogForTable(
 	err := r.executor.Run(ctx, &items, q)
 	return items, err
 }
+
+func (r *changelogRepository) ListChangelogForDatabase(
+	ctx context.Context,
+	databaseID int64,
+) ([]*entities.ChangelogItemWithUserInfo, error) {
+	q := sqrl.Select("*").
+		From(changelogTableWithShortName).
+		Join(usersTableWithShortName + " on cl.user_id = u.id").
+		Where(sqrl.And{
+			sqrl.Eq{"cl.target": entities.ChangeTargetDatabase},
+			sqrl.Eq{"cl.database_id": databaseID},
+		}).
+		PlaceholderFormat(sqrl.Dollar).
+		OrderBy("changed_at ASC")
+
+	var items []*entities.ChangelogItemWithUserInfo
+	err := r.executor.Run(ctx, &items, q)
+	return items, err
+}
+
+func (r *changelogRepository) ListTableItems(ctx context.Context, tableID string) ([]*entities.ChangelogItem, error) {
+	q := sqrl.Select("*").
+		From(changelogTable).
+		Where(sqrl.Eq{"table_id": tableID}).
+		Where(sqrl.NotEq{"target": entities.ChangeTargetDatabase}).
+		PlaceholderFormat(sqrl.Dollar).
+		OrderBy("change_id ASC")
+
+	var items []*entities.ChangelogItem
+	err := r.executor.Run(ctx, &items, q)
+	return items, err
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+type databaseInvitationsRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewDatabaseInvitationsRepository(executor sql_executor.ISQLExecutor) IDatabaseInvitationsRepository {
+	return &databaseInvitationsRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *databaseInvitationsRepository) UpsertInvitation(ctx context.Context, invitation *entities.DatabaseInvitation) (*entities.DatabaseInvitation, error) {
+	q := sqrl.Insert(databaseInvitationsTable).
+		Columns("database_id, email, role, invited_by, user_id, expires_at").
+		Values(invitation.DatabaseID, invitation.Email, invitation.Role, invitation.InvitedBy, invitation.UserID, invitation.ExpiresAt).
+		PlaceholderFormat(sqrl.Dollar).
+		Suffix(`ON CONFLICT (database_id, lower(email)) WHERE status = 'pending' DO UPDATE SET
+role = EXCLUDED.role,
+invited_by = EXCLUDED.invited_by,
+user_id = EXCLUDED.user_id,
+expires_at = EXCLUDED.expires_at RETURNING *`)
+
+	upsertedInvitation := &entities.DatabaseInvitation{}
+	err := r.executor.Run(ctx, upsertedInvitation, q)
+	if err != nil {
+		return nil, err
+	}
+	return upsertedInvitation, nil
+}
+
+func (r *databaseInvitationsRepository) GetInvitationByID(ctx context.Context, id int64) (*entities.DatabaseInvitation, error) {
+	q := sqrl.Select("*").
+		From(databaseInvitationsTable).
+		Where(sqrl.Eq{"id": id}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	invitation := &entities.DatabaseInvitation{}
+	err := r.executor.Run(ctx, invitation, q)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (r *databaseInvitationsRepository) ListPendingDatabaseInvitations(ctx context.Context, databaseID int64) ([]*entities.DatabaseInvitation, error) {
+	q := r.selectPending().
+		Where(sqrl.Eq{"i.database_id": databaseID}).
+		OrderBy("i.created_at desc")
+
+	var invitations []*entities.DatabaseInvitation
+	err := r.executor.Run(ctx, &invitations, q)
+	return invitations, err
+}
+
+func (r *databaseInvitationsRepository) ListPendingUserInvitations(ctx context.Context, userID int64) ([]*entities.DatabaseInvitation, error) {
+	q := r.selectPending().
+		Where(sqrl.Eq{"i.user_id": userID}).
+		OrderBy("i.created_at desc")
+
+	var invitations []*entities.DatabaseInvitation
+	err := r.executor.Run(ctx, &invitations, q)
+	return invitations, err
+}
+
+func (r *databaseInvitationsRepository) selectPending() *sqrl.SelectBuilder {
+	return sqrl.Select("i.*, db.name as database_name, u.name as inviter_name").
+		From(databaseInvitationsTableWithShortName).
+		Join(databasesTableWithShortName + " on i.database_id = db.id").
+		Join(usersTableWithShortName + " on i.invited_by = u.id").
+		Where(sqrl.Eq{"i.status": entities.InvitationStatusPending, "db.deleted_at": nil}).
+		Where(sqrl.Gt{"i.expires_at": time.Now()}).
+		PlaceholderFormat(sqrl.Dollar)
+}
+
+func (r *databaseInvitationsRepository) RespondToInvitation(ctx context.Context, id int64, status entities.InvitationStatus) (bool, error) {
+	q := sqrl.Update(databaseInvitationsTable).
+		Set("status", status).
+		Set("responded_at", time.Now()).
+		Where(sqrl.Eq{"id": id, "status": entities.InvitationStatusPending}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	res, err := r.executor.Exec(ctx, q)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *databaseInvitationsRepository) LinkInvitationsToUser(ctx context.Context, userID int64, email string) ([]*entities.DatabaseInvitation, error) {
+	q := sqrl.Update(databaseInvitationsTable).
+		Set("user_id", userID).
+		Where(sqrl.Eq{"status": entities.InvitationStatusPending, "user_id": nil}).
+		Where("lower(email) = lower(?)", email).
+		Where(sqrl.Gt{"expires_at": time.Now()}).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	var invitations []*entities.DatabaseInvitation
+	err := r.executor.Run(ctx, &invitations, q)
+	return invitations, err
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+type databaseRolesRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewDatabaseRolesRepository(executor sql_executor.ISQLExecutor) IDatabaseRolesRepository {
+	return &databaseRolesRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *databaseRolesRepository) CreateRole(ctx context.Context, role *entities.DatabaseRole) (*entities.DatabaseRole, error) {
+	q := sqrl.Insert(databaseRolesTable).
+		Columns("database_id, name, permissions").
+		Values(role.DatabaseID, role.Name, role.Permissions).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	createdRole := &entities.DatabaseRole{}
+	err := r.executor.Run(ctx, createdRole, q)
+	if err != nil {
+		return nil, err
+	}
+	return createdRole, nil
+}
+
+func (r *databaseRolesRepository) UpdateRolePermissions(
+	ctx context.Context,
+	databaseID int64,
+	name entities.Role,
+	permissions entities.Permissions,
+) (*entities.DatabaseRole, error) {
+	value := entities.JSONB[entities.Permissions]{}
+	value.Set(permissions)
+
+	q := sqrl.Update(databaseRolesTable).
+		Set("permissions", value).
+		Set("updated_at", time.Now()).
+		Where(sqrl.Eq{"database_id": databaseID, "name": name}).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	updatedRole := &entities.DatabaseRole{}
+	err := r.executor.Run(ctx, updatedRole, q)
+	if err != nil {
+		return nil, err
+	}
+	return updatedRole, nil
+}
+
+func (r *databaseRolesRepository) DeleteRole(ctx context.Context, databaseID int64, name entities.Role) (bool, error) {
+	q := sqrl.Delete(databaseRolesTable).
+		Where(sqrl.Eq{"database_id": databaseID, "name": name}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	res, err := r.executor.Exec(ctx, q)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *databaseRolesRepository) GetRole(ctx context.Context, databaseID int64, name entities.Role) (*entities.DatabaseRole, error) {
+	q := sqrl.Select("*").
+		From(databaseRolesTable).
+		Where(sqrl.Eq{"database_id": databaseID, "name": name}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	role := &entities.DatabaseRole{}
+	err := r.executor.Run(ctx, role, q)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *databaseRolesRepository) ListDatabaseRoles(ctx context.Context, databaseID int64) ([]*entities.DatabaseRole, error) {
+	q := sqrl.Select("*").
+		From(databaseRolesTable).
+		Where(sqrl.Eq{"database_id": databaseID}).
+		OrderBy("name ASC").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var roles []*entities.DatabaseRole
+	err := r.executor.Run(ctx, &roles, q)
+	return roles, err
+}
+
+func (r *databaseRolesRepository) CountRoleUsages(ctx context.Context, databaseID int64, name entities.Role) (int64, error) {
+	members := sqrl.Select("count(*)").
+		From(usersDatabasesTable).
+		Where(sqrl.Eq{"database_id": databaseID, "role": name, "deleted_at": nil})
+	overrides := sqrl.Select("count(*)").
+		From(usersTablesTable).
+		Where(sqrl.Eq{"role": name}).
+		Where("table_id in (select id from "+tablesTable+" where database_id = ?)", databaseID)
+	invitations := sqrl.Select("count(*)").
+		From(databaseInvitationsTable).
+		Where(sqrl.Eq{"database_id": databaseID, "role": name, "status": entities.InvitationStatusPending})
+
+	q := sqrl.Select().
+		Column(sqrl.Alias(members, "members")).
+		Column(sqrl.Alias(overrides, "overrides")).
+		Column(sqrl.Alias(invitations, "invitations")).
+		PlaceholderFormat(sqrl.Dollar)
+
+	usages := struct {
+		Members     int64 `db:"members"`
+		Overrides   int64 `db:"overrides"`
+		Invitations int64 `db:"invitations"`
+	}{}
+	err := r.executor.Run(ctx, &usages, q)
+	return usages.Members + usages.Overrides + usages.Invitations, err
+}
@@ -21,10 +21,10 @@ func NewDatabasesRepository(executor sql_executor.ISQLExecutor) IDatabasesReposi
 	}
 }
 
-func (r *databasesRepository) AddDatabase(ctx context.Context, name string) (*entities.Database, error) {
+func (r *databasesRepository) AddDatabase(ctx context.Context, name string, ownerID int64, organizationID *int64) (*entities.Database, error) {
 	q := sqrl.Insert(databasesTable).
-		Columns("name").
-		Values(name).
+		Columns("name, owner_id, organization_id").
+		Values(name, ownerID, organizationID).
 		PlaceholderFormat(sqrl.Dollar).
 		Returning("*")
 
@@ -77,8 +77,69 @@ func (r *databasesRepository) GetDatabaseByID(ctx context.Context, id int64) (*e
 	return dbDatabase, err
 }
 
+func (r *databasesRepository) RenameDatabase(ctx context.Context, id int64, name string) (*entities.Database, error) {
+	q := sqrl.Update(databasesTable).
+		Set("name", name).
+		Where(sqrl.Eq{"id": id, "deleted_at": nil}).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	dbDatabase := &entities.Database{}
+	err := r.executor.Run(ctx, dbDatabase, q)
+	if err != nil {
+		return nil, err
+	}
+	return dbDatabase, err
+}
+
+func (r *databasesRepository) DeleteDatabase(ctx context.Context, id int64) error {
+	// Tables get exactly the same deleted_at as the database, which lets the restore
+	// tell them apart from tables that had been deleted on their own before.
+	now := time.Now()
+	q := sqrl.Update(tablesTable).
+		Prefix("WITH deleted_db AS (UPDATE "+databasesTable+" SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL RETURNING id)", now, id).
+		Set("deleted_at", now).
+		Where("database_id IN (SELECT id FROM deleted_db)").
+		Where(sqrl.Eq{"deleted_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *databasesRepository) RestoreDatabase(ctx context.Context, id int64) error {
+	q := sqrl.Update(tablesTable).
+		Prefix(`WITH deleted_db AS (SELECT id, deleted_at FROM `+databasesTable+` WHERE id = ? AND deleted_at IS NOT NULL),
+restored_db AS (UPDATE `+databasesTable+` SET deleted_at = NULL WHERE id IN (SELECT id FROM deleted_db))`, id).
+		Set("deleted_at", nil).
+		Where(sqrl.Eq{"database_id": id}).
+		Where("deleted_at = (SELECT deleted_at FROM deleted_db)").
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *databasesRepository) GetUsersDeletedDatabases(ctx context.Context, userID int64, role entities.Role) ([]*entities.UsersDatabase, error) {
+	q := sqrl.Select("udb.*, db.name, db.owner_id, db.organization_id, db.deleted_at as database_deleted_at").
+		From(usersDatabasesTableWithShortName).
+		Join(databasesTableWithShortName + " on udb.database_id = db.id").
+		Where(sqrl.And{
+			sqrl.Eq{"udb.user_id": userID},
+			sqrl.Eq{"udb.role": role},
+			sqrl.Eq{"udb.deleted_at": nil},
+			sqrl.NotEq{"db.deleted_at": nil},
+		}).
+		OrderBy("db.deleted_at desc").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var usersDatabases []*entities.UsersDatabase
+	err := r.executor.Run(ctx, &usersDatabases, q)
+	return usersDatabases, err
+}
+
 func (r *databasesRepository) GetUsersDatabases(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error) {
-	q := sqrl.Select("udb.*, db.name").
+	q := sqrl.Select("udb.*, db.name, db.owner_id, db.organization_id").
 		From(usersDatabasesTableWithShortName).
 		Join(databasesTableWithShortName + " on udb.database_id = db.id").
 		Where(sqrl.And{
@@ -93,10 +154,25 @@ func (r *databasesRepository) GetUsersDatabases(ctx context.Context, userID int6
 	return usersDatabases, err
 }
 
+func (r *databasesRepository) LockUsersMemberships(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error) {
+	q := sqrl.Select("udb.*, db.name, db.owner_id, db.organization_id, db.deleted_at as database_deleted_at").
+		From(usersDatabasesTableWithShortName).
+		Join(databasesTableWithShortName + " on udb.database_id = db.id").
+		Where(sqrl.Eq{"udb.user_id": userID, "udb.deleted_at": nil}).
+		OrderBy("db.id").
+		Suffix("FOR UPDATE OF db").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var usersDatabases []*entities.UsersDatabase
+	err := r.executor.Run(ctx, &usersDatabases, q)
+	return usersDatabases, err
+}
+
 func (r *databasesRepository) GetDatabasesUsers(ctx context.Context, databaseID int64) ([]*entities.DatabasesUser, error) {
-	q := sqrl.Select("u.*, udb.role").
+	q := sqrl.Select("u.*, udb.role, coalesce(db.owner_id = u.id, false) as is_owner").
 		From(usersDatabasesTableWithShortName).
 		Join(usersTableWithShortName + " on udb.user_id = u.id").
+		Join(databasesTableWithShortName + " on udb.database_id = db.id").
 		Where(sqrl.And{
 			sqrl.Eq{"udb.database_id": databaseID},
 			sqrl.Eq{"udb.deleted_at": nil},
@@ -110,30 +186,120 @@ func (r *databasesRepository) GetDatabasesUsers(ctx context.Context, databaseID
 }
 
 func (r *databasesRepository) GetDatabasesUsersIDs(ctx context.Context, databaseID int64) ([]int64, error) {
+	organizationAdmins := sqrl.Select("uo.user_id").
+		From(usersOrganizationsTableWithShortName).
+		Join(databasesTableWithShortName + " on db.organization_id = uo.organization_id").
+		Where(sqrl.Eq{"db.id": databaseID, "uo.role": entities.OrganizationRoleAdmin})
+	organizationAdminsSQL, organizationAdminsArgs, err := organizationAdmins.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
 	q := sqrl.Select("user_id").
 		From(usersDatabasesTable).
 		Where(sqrl.And{
 			sqrl.Eq{"database_id": databaseID},
 			sqrl.Eq{"deleted_at": nil},
 		}).
+		Suffix("union "+organizationAdminsSQL, organizationAdminsArgs...).
 		PlaceholderFormat(sqrl.Dollar)
 
 	var ids []int64
-	err := r.executor.Run(ctx, &ids, q)
+	err = r.executor.Run(ctx, &ids, q)
 	return ids, err
 }
 
-func (r *databasesRepository) GetUsersDatabaseRole(ctx context.Context, userID, databaseID int64) (entities.Role, error) {
-	q := sqrl.Select("role").
-		From(usersDatabasesTable).
+func (r *databasesRepository) GetUsersDatabaseRole(ctx context.Context, userID, databaseID int64, withDeleted bool) (entities.Role, error) {
+	q := sqrl.Select("udb.role").
+		From(usersDatabasesTableWithShortName).
+		Join(databasesTableWithShortName + " on udb.database_id = db.id").
 		Where(sqrl.And{
-			sqrl.Eq{"user_id": userID},
-			sqrl.Eq{"database_id": databaseID},
-			sqrl.Eq{"deleted_at": nil},
+			sqrl.Eq{"udb.user_id": userID},
+			sqrl.Eq{"udb.database_id": databaseID},
+			sqrl.Eq{"udb.deleted_at": nil},
 		}).
 		PlaceholderFormat(sqrl.Dollar)
 
+	if !withDeleted {
+		q = q.Where(sqrl.Eq{"db.deleted_at": nil})
+	}
+
 	var role entities.Role
 	err := r.executor.Run(ctx, &role, q)
 	return role, err
 }
+
+func (r *databasesRepository) ListDatabasesUserCannotLeave(ctx context.Context, userID int64) ([]*entities.Database, error) {
+	otherAdmins := sqrl.Select("1").
+		From("app.users_databases as oudb").
+		Join("app.users as ou on oudb.user_id = ou.id").
+		Where("oudb.database_id = db.id").
+		Where(sqrl.NotEq{"oudb.user_id": userID}).
+		Where(sqrl.Eq{"oudb.role": entities.RoleAdmin, "oudb.deleted_at": nil, "ou.deleted_at": nil})
+	otherAdminsSQL, otherAdminsArgs, err := otherAdmins.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	q := sqrl.Select("db.*").
+		From(databasesTableWithShortName).
+		Join(usersDatabasesTableWithShortName + " on udb.database_id = db.id").
+		Where(sqrl.Eq{
+			"udb.user_id":    userID,
+			"udb.role":       entities.RoleAdmin,
+			"udb.deleted_at": nil,
+		}).
+		Where(sqrl.Or{
+			sqrl.Eq{"db.owner_id": userID},
+			sqrl.Expr("not exists ("+otherAdminsSQL+")", otherAdminsArgs...),
+		}).
+		OrderBy("db.name").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var databases []*entities.Database
+	err = r.executor.Run(ctx, &databases, q)
+	return databases, err
+}
+
+func (r *databasesRepository) LockDatabase(ctx context.Context, id int64) (*entities.Database, error) {
+	q := sqrl.Select("*").
+		From(databasesTable).
+		Where(sqrl.Eq{"id": id, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sqrl.Dollar)
+
+	dbDatabase := &entities.Database{}
+	err := r.executor.Run(ctx, dbDatabase, q)
+	if err != nil {
+		return nil, err
+	}
+	return dbDatabase, err
+}
+
+func (r *databasesRepository) CountOtherAdmins(ctx context.Context, databaseID, userID int64) (int64, error) {
+	q := sqrl.Select("count(*)").
+		From(usersDatabasesTableWithShortName).
+		Join(usersTableWithShortName + " on udb.user_id = u.id").
+		Where(sqrl.Eq{
+			"udb.database_id": databaseID,
+			"udb.role":        entities.RoleAdmin,
+			"udb.deleted_at":  nil,
+			"u.deleted_at":    nil,
+		}).
+		Where(sqrl.NotEq{"udb.user_id": userID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	var count int64
+	err := r.executor.Run(ctx, &count, q)
+	return count, err
+}
+
+func (r *databasesRepository) SetDatabaseOwner(ctx context.Context, id int64, ownerID int64) error {
+	q := sqrl.Update(databasesTable).
+		Set("owner_id", ownerID).
+		Where(sqrl.Eq{"id": id}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"backend/src/domains/entities"
 	"context"
+	"time"
 )
 
 const (
@@ -16,6 +17,23 @@ const (
 	usersDatabasesTableWithShortName = "app.users_databases as udb"
 	changelogTable                   = "app.changelog"
 	changelogTableWithShortName      = "app.changelog as cl"
+	sessionsTable                    = "app.sessions"
+	personalAccessTokensTable        = "app.personal_access_tokens"
+	userTokensTable                  = "app.user_tokens"
+	userIdentitiesTable              = "app.user_identities"
+	oidcLoginRequestsTable           = "app.oidc_login_requests"
+
+	databaseRolesTable            = "app.database_roles"
+	tableShareLinksTable          = "app.table_share_links"
+	usersTablesTable              = "app.users_tables"
+	usersTablesTableWithShortName = "app.users_tables as ut"
+
+	organizationsTable                   = "app.organizations"
+	usersOrganizationsTable              = "app.users_organizations"
+	usersOrganizationsTableWithShortName = "app.users_organizations as uo"
+
+	databaseInvitationsTable              = "app.database_invitations"
+	databaseInvitationsTableWithShortName = "app.database_invitations as i"
 )
 
 type ICommonRepository interface {
@@ -27,7 +45,30 @@ type IUsersRepository interface {
 	CreateUser(ctx context.Context, user *entities.User) (*entities.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*entities.User, error)
 	GetUserByID(ctx context.Context, id int64) (*entities.User, error)
-	ListUsers(ctx context.Context) ([]*entities.User, error)
+	UpdateUserPassword(ctx context.Context, id int64, password string) error
+	MarkEmailVerified(ctx context.Context, id int64, email string) (bool, error)
+	UpdateUserName(ctx context.Context, id int64, name string) (*entities.User, error)
+	// UpdateUserEmail sets an already verified email.
+	UpdateUserEmail(ctx context.Context, id int64, email string) (*entities.User, error)
+	DeleteUser(ctx context.Context, id int64) error
+	// SearchUsers looks for users sharing a database with the caller, or having exactly the searched email.
+	SearchUsers(ctx context.Context, callerID int64, params entities.SearchUsersParams) ([]*entities.User, error)
+	GetTotalSearchUsers(ctx context.Context, callerID int64, params entities.SearchUsersParams) (int64, error)
+}
+
+type IUserIdentitiesRepository interface {
+	ICommonRepository
+	CreateIdentity(ctx context.Context, identity *entities.UserIdentity) error
+	GetIdentity(ctx context.Context, issuer, subject string) (*entities.UserIdentity, error)
+	DeleteUserIdentities(ctx context.Context, userID int64) error
+}
+
+type IOIDCLoginRequestsRepository interface {
+	ICommonRepository
+	CreateLoginRequest(ctx context.Context, request *entities.OIDCLoginRequest) error
+	// ConsumeLoginRequest deletes the unexpired request with the state and returns it.
+	ConsumeLoginRequest(ctx context.Context, state string) (*entities.OIDCLoginRequest, error)
+	DeleteExpiredLoginRequests(ctx context.Context) error
 }
 
 type ITablesRepository interface {
@@ -37,31 +78,127 @@ type ITablesRepository interface {
 	RestoreTable(ctx context.Context, id string) error
 	GetTableByID(ctx context.Context, id string, withDeleted bool) (*entities.Table, error)
 	UpdateTable(ctx context.Context, table *entities.Table) error
+	// CopyRows copies every row of the source table, deleted ones included, into the empty target table.
+	// columnIDs maps the source column IDs to the target ones, unmapped columns are not copied.
+	CopyRows(ctx context.Context, source, target *entities.Table, columnIDs map[string]string) error
 	ListByDatabaseID(ctx context.Context, databaseID int64) ([]*entities.Table, error)
 	ListIDsByDatabaseID(ctx context.Context, databaseID int64) ([]string, error)
 	ListByDatabaseIDs(ctx context.Context, databaseIDs []int64) ([]*entities.Table, error)
-	AddRow(ctx context.Context, table *entities.Table, data map[string]*string, sortIndex *int64) (entities.TableRow, error)
+	AddRow(ctx context.Context, table *entities.Table, createdBy int64, data map[string]*string, sortIndex *int64) (entities.TableRow, error)
 	DeleteRow(ctx context.Context, tableID string, rowID int64) (entities.TableRow, error)
 	RestoreRow(ctx context.Context, tableID string, rowID int64) error
 	MoveRow(ctx context.Context, tableID string, rowID int64, sortIndex int64) error
-	SetCellValue(ctx context.Context, tableID string, rowID int64, columnID string, value *string) (*entities.RawCellChangeInfo, error)
+	SetCellValue(ctx context.Context, tableID string, rowID int64, columnID string, value *string, updatedBy int64) (*entities.RawCellChangeInfo, error)
 	ReadTable(ctx context.Context, table *entities.Table, params *entities.ReadTableParams) ([]entities.TableRow, error)
 	GetTotalRows(ctx context.Context, table *entities.Table, params *entities.ReadTableParams) (int64, error)
-	AddRows(ctx context.Context, table *entities.Table, data []map[string]*string) error
-	AddFullFilledRows(ctx context.Context, table *entities.Table, rows [][]*string) error
+	// IsRowAccessible reports whether the row, deleted or not, passes the row policies of the table view.
+	IsRowAccessible(ctx context.Context, table *entities.Table, rowID int64) (bool, error)
+	// FilterAccessibleRowIDs returns the IDs of the rows, deleted or not, passing the row policies of the table view.
+	FilterAccessibleRowIDs(ctx context.Context, table *entities.Table, ids []int64) ([]int64, error)
+	// GetRowsByIDs returns the rows of the table view with the given IDs, deleted rows are skipped.
+	GetRowsByIDs(ctx context.Context, table *entities.Table, ids []int64) ([]entities.TableRow, error)
+	AddRows(ctx context.Context, table *entities.Table, createdBy int64, data []map[string]*string) error
+	AddFullFilledRows(ctx context.Context, table *entities.Table, createdBy int64, rows [][]*string) error
 	GetDistinctValues(ctx context.Context, tableID, columnID string) ([]*string, error)
+	// ReplaceColumnValues rewrites the cells of the column holding a key of replacements to its value.
+	ReplaceColumnValues(ctx context.Context, tableID, columnID string, replacements map[string]string) error
 }
 
 type IDatabasesRepository interface {
 	ICommonRepository
-	AddDatabase(ctx context.Context, name string) (*entities.Database, error)
+	AddDatabase(ctx context.Context, name string, ownerID int64, organizationID *int64) (*entities.Database, error)
 	UpsertUsersDatabase(ctx context.Context, usersDatabase *entities.UsersDatabase) (*entities.UsersDatabase, error)
 	DeleteUsersDatabaseRelation(ctx context.Context, userID, databaseID int64) error
 	GetDatabaseByID(ctx context.Context, id int64) (*entities.Database, error)
 	GetUsersDatabases(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error)
+	// LockUsersMemberships returns the memberships of the user in every database, deleted ones included,
+	// selecting the databases FOR UPDATE.
+	LockUsersMemberships(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error)
 	GetDatabasesUsers(ctx context.Context, databaseID int64) ([]*entities.DatabasesUser, error)
+	// GetDatabasesUsersIDs returns the members of the database together with the admins of its organization.
 	GetDatabasesUsersIDs(ctx context.Context, databaseID int64) ([]int64, error)
-	GetUsersDatabaseRole(ctx context.Context, userID, databaseID int64) (entities.Role, error)
+	GetUsersDatabaseRole(ctx context.Context, userID, databaseID int64, withDeleted bool) (entities.Role, error)
+	RenameDatabase(ctx context.Context, id int64, name string) (*entities.Database, error)
+	// DeleteDatabase soft-deletes the database together with its tables.
+	DeleteDatabase(ctx context.Context, id int64) error
+	// RestoreDatabase restores the database and the tables deleted together with it.
+	RestoreDatabase(ctx context.Context, id int64) error
+	GetUsersDeletedDatabases(ctx context.Context, userID int64, role entities.Role) ([]*entities.UsersDatabase, error)
+	// ListDatabasesUserCannotLeave returns databases, deleted ones included, the user owns or is the only admin left of.
+	ListDatabasesUserCannotLeave(ctx context.Context, userID int64) ([]*entities.Database, error)
+	// LockDatabase selects the database FOR UPDATE, serializing membership changes within a transaction.
+	LockDatabase(ctx context.Context, id int64) (*entities.Database, error)
+	CountOtherAdmins(ctx context.Context, databaseID, userID int64) (int64, error)
+	SetDatabaseOwner(ctx context.Context, id int64, ownerID int64) error
+}
+
+// IDatabaseRolesRepository stores the custom roles of databases.
+type IDatabaseRolesRepository interface {
+	ICommonRepository
+	CreateRole(ctx context.Context, role *entities.DatabaseRole) (*entities.DatabaseRole, error)
+	UpdateRolePermissions(ctx context.Context, databaseID int64, name entities.Role, permissions entities.Permissions) (*entities.DatabaseRole, error)
+	DeleteRole(ctx context.Context, databaseID int64, name entities.Role) (bool, error)
+	GetRole(ctx context.Context, databaseID int64, name entities.Role) (*entities.DatabaseRole, error)
+	ListDatabaseRoles(ctx context.Context, databaseID int64) ([]*entities.DatabaseRole, error)
+	// CountRoleUsages counts the members, table overrides and pending invitations with the role.
+	CountRoleUsages(ctx context.Context, databaseID int64, name entities.Role) (int64, error)
+}
+
+type ITableShareLinksRepository interface {
+	ICommonRepository
+	CreateLink(ctx context.Context, link *entities.TableShareLink) (*entities.TableShareLink, error)
+	GetLinkByHash(ctx context.Context, hash string) (*entities.TableShareLink, error)
+	// ListTableLinks returns the links of the table that were not revoked, expired ones included.
+	ListTableLinks(ctx context.Context, tableID string) ([]*entities.TableShareLink, error)
+	RevokeLink(ctx context.Context, tableID string, id int64) (bool, error)
+}
+
+// IUsersTablesRepository stores per-table overrides of database roles.
+type IUsersTablesRepository interface {
+	ICommonRepository
+	UpsertUsersTable(ctx context.Context, usersTable *entities.UsersTable) (*entities.UsersTable, error)
+	DeleteUsersTable(ctx context.Context, userID int64, tableID string) error
+	// DeleteUsersDatabaseTables removes the user's overrides for every table of the database.
+	DeleteUsersDatabaseTables(ctx context.Context, userID, databaseID int64) error
+	DeleteTableUsers(ctx context.Context, tableID string) error
+	GetUsersTableRole(ctx context.Context, userID int64, tableID string) (entities.Role, error)
+	GetUsersTables(ctx context.Context, userID int64) ([]*entities.UsersTable, error)
+	GetTablesUsers(ctx context.Context, tableID string) ([]*entities.TablesUser, error)
+}
+
+type IOrganizationsRepository interface {
+	ICommonRepository
+	AddOrganization(ctx context.Context, name string) (*entities.Organization, error)
+	GetOrganizationByID(ctx context.Context, id int64) (*entities.Organization, error)
+	// LockOrganization selects the organization FOR UPDATE, serializing membership changes within a transaction.
+	LockOrganization(ctx context.Context, id int64) (*entities.Organization, error)
+	UpsertUsersOrganization(ctx context.Context, usersOrganization *entities.UsersOrganization) (*entities.UsersOrganization, error)
+	DeleteUsersOrganization(ctx context.Context, userID, organizationID int64) error
+	GetUsersOrganizationRole(ctx context.Context, userID, organizationID int64) (entities.OrganizationRole, error)
+	GetUsersOrganizations(ctx context.Context, userID int64) ([]*entities.UsersOrganization, error)
+	GetOrganizationsUsers(ctx context.Context, organizationID int64) ([]*entities.OrganizationsUser, error)
+	CountOtherAdmins(ctx context.Context, organizationID, userID int64) (int64, error)
+	// IsDatabaseOrganizationAdmin reports whether the user administers the organization the database belongs to.
+	IsDatabaseOrganizationAdmin(ctx context.Context, userID, databaseID int64, withDeleted bool) (bool, error)
+	// GetAdministeredDatabases returns the deleted or the live databases of the organizations the user administers.
+	GetAdministeredDatabases(ctx context.Context, userID int64, deleted bool) ([]*entities.Database, error)
+	GetOrganizationDatabases(ctx context.Context, organizationID int64) ([]*entities.Database, error)
+	SetDatabaseOrganization(ctx context.Context, databaseID int64, organizationID *int64) error
+	// GrantMembersDatabaseRole adds the organization members who are not members of the database yet
+	// to it with the role, and returns their IDs. Existing members keep their roles.
+	GrantMembersDatabaseRole(ctx context.Context, organizationID, databaseID int64, role entities.Role) ([]int64, error)
+}
+
+type IDatabaseInvitationsRepository interface {
+	ICommonRepository
+	// UpsertInvitation creates a pending invitation or refreshes the pending one for the same email.
+	UpsertInvitation(ctx context.Context, invitation *entities.DatabaseInvitation) (*entities.DatabaseInvitation, error)
+	GetInvitationByID(ctx context.Context, id int64) (*entities.DatabaseInvitation, error)
+	ListPendingDatabaseInvitations(ctx context.Context, databaseID int64) ([]*entities.DatabaseInvitation, error)
+	ListPendingUserInvitations(ctx context.Context, userID int64) ([]*entities.DatabaseInvitation, error)
+	// RespondToInvitation moves a pending invitation to the final status, reporting whether it was still pending.
+	RespondToInvitation(ctx context.Context, id int64, status entities.InvitationStatus) (bool, error)
+	LinkInvitationsToUser(ctx context.Context, userID int64, email string) ([]*entities.DatabaseInvitation, error)
 }
 
 type IChangelogRepository interface {
@@ -77,4 +214,48 @@ type IChangelogRepository interface {
 		ctx context.Context,
 		tableID string,
 	) ([]*entities.ChangelogItemWithUserInfo, error)
+	// ListChangelogForDatabase returns the changes of the database itself, its members and its tables.
+	ListChangelogForDatabase(
+		ctx context.Context,
+		databaseID int64,
+	) ([]*entities.ChangelogItemWithUserInfo, error)
+	// ListTableItems returns every item of the table, cell changes included, oldest first.
+	// Database items about the table are left out.
+	ListTableItems(ctx context.Context, tableID string) ([]*entities.ChangelogItem, error)
+}
+
+type ISessionsRepository interface {
+	ICommonRepository
+	CreateSession(ctx context.Context, session *entities.Session) (*entities.Session, error)
+	GetSessionByID(ctx context.Context, id string) (*entities.Session, error)
+	GetSessionByRefreshTokenHash(ctx context.Context, hash string) (*entities.Session, error)
+	RotateRefreshToken(
+		ctx context.Context,
+		id string,
+		oldHash string,
+		newHash string,
+		expiresAt time.Time,
+		client entities.SessionClient,
+	) (*entities.Session, error)
+	RevokeSession(ctx context.Context, id string) error
+	RevokeUserSessions(ctx context.Context, userID int64) error
+	RevokeOtherUserSessions(ctx context.Context, userID int64, keepSessionID string) error
+	ListActiveUserSessions(ctx context.Context, userID int64) ([]*entities.Session, error)
+}
+
+type IPersonalAccessTokensRepository interface {
+	ICommonRepository
+	CreateToken(ctx context.Context, token *entities.PersonalAccessToken) (*entities.PersonalAccessToken, error)
+	GetTokenByHash(ctx context.Context, hash string) (*entities.PersonalAccessToken, error)
+	ListUserTokens(ctx context.Context, userID int64) ([]*entities.PersonalAccessToken, error)
+	TouchToken(ctx context.Context, id int64, usedAt time.Time) error
+	RevokeToken(ctx context.Context, userID int64, id int64) (bool, error)
+	RevokeUserTokens(ctx context.Context, userID int64) error
+}
+
+type IUserTokensRepository interface {
+	ICommonRepository
+	CreateToken(ctx context.Context, token *entities.UserToken) (*entities.UserToken, error)
+	ConsumeToken(ctx context.Context, purpose entities.UserTokenPurpose, hash string) (*entities.UserToken, error)
+	InvalidateUserTokens(ctx context.Context, userID int64, purpose entities.UserTokenPurpose) error
 }
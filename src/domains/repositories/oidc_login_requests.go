@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+type oidcLoginRequestsRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewOIDCLoginRequestsRepository(executor sql_executor.ISQLExecutor) IOIDCLoginRequestsRepository {
+	return &oidcLoginRequestsRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *oidcLoginRequestsRepository) CreateLoginRequest(ctx context.Context, request *entities.OIDCLoginRequest) error {
+	q := sqrl.Insert(oidcLoginRequestsTable).
+		Columns("state, nonce, code_verifier, expires_at").
+		Values(request.State, request.Nonce, request.CodeVerifier, request.ExpiresAt).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *oidcLoginRequestsRepository) ConsumeLoginRequest(ctx context.Context, state string) (*entities.OIDCLoginRequest, error) {
+	q := sqrl.Delete(oidcLoginRequestsTable).
+		Where(sqrl.Eq{"state": state}).
+		Where(sqrl.Gt{"expires_at": time.Now()}).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	request := &entities.OIDCLoginRequest{}
+	err := r.executor.Run(ctx, request, q)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *oidcLoginRequestsRepository) DeleteExpiredLoginRequests(ctx context.Context) error {
+	q := sqrl.Delete(oidcLoginRequestsTable).
+		Where(sqrl.LtOrEq{"expires_at": time.Now()}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
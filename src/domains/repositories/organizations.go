@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+
+	"github.com/elgris/sqrl"
+)
+
+type organizationsRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewOrganizationsRepository(executor sql_executor.ISQLExecutor) IOrganizationsRepository {
+	return &organizationsRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *organizationsRepository) AddOrganization(ctx context.Context, name string) (*entities.Organization, error) {
+	q := sqrl.Insert(organizationsTable).
+		Columns("name").
+		Values(name).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	createdOrganization := &entities.Organization{}
+	err := r.executor.Run(ctx, createdOrganization, q)
+	if err != nil {
+		return nil, err
+	}
+	return createdOrganization, nil
+}
+
+func (r *organizationsRepository) GetOrganizationByID(ctx context.Context, id int64) (*entities.Organization, error) {
+	q := sqrl.Select("*").
+		From(organizationsTable).
+		Where(sqrl.Eq{"id": id}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	organization := &entities.Organization{}
+	err := r.executor.Run(ctx, organization, q)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (r *organizationsRepository) LockOrganization(ctx context.Context, id int64) (*entities.Organization, error) {
+	q := sqrl.Select("*").
+		From(organizationsTable).
+		Where(sqrl.Eq{"id": id}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sqrl.Dollar)
+
+	organization := &entities.Organization{}
+	err := r.executor.Run(ctx, organization, q)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (r *organizationsRepository) UpsertUsersOrganization(
+	ctx context.Context,
+	usersOrganization *entities.UsersOrganization,
+) (*entities.UsersOrganization, error) {
+	q := sqrl.Insert(usersOrganizationsTable).
+		Columns("user_id, organization_id, role").
+		Values(usersOrganization.UserID, usersOrganization.OrganizationID, usersOrganization.Role).
+		PlaceholderFormat(sqrl.Dollar).
+		Suffix(`ON CONFLICT on constraint users_organizations_pkey do update SET
+role = EXCLUDED.role,
+updated_at = now() RETURNING *`)
+
+	upsertedUsersOrganization := &entities.UsersOrganization{}
+	err := r.executor.Run(ctx, upsertedUsersOrganization, q)
+	if err != nil {
+		return nil, err
+	}
+	return upsertedUsersOrganization, nil
+}
+
+func (r *organizationsRepository) DeleteUsersOrganization(ctx context.Context, userID, organizationID int64) error {
+	q := sqrl.Delete(usersOrganizationsTable).
+		Where(sqrl.Eq{"user_id": userID, "organization_id": organizationID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *organizationsRepository) GetUsersOrganizationRole(
+	ctx context.Context,
+	userID, organizationID int64,
+) (entities.OrganizationRole, error) {
+	q := sqrl.Select("role").
+		From(usersOrganizationsTable).
+		Where(sqrl.Eq{"user_id": userID, "organization_id": organizationID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	var role entities.OrganizationRole
+	err := r.executor.Run(ctx, &role, q)
+	return role, err
+}
+
+func (r *organizationsRepository) GetUsersOrganizations(ctx context.Context, userID int64) ([]*entities.UsersOrganization, error) {
+	q := sqrl.Select("uo.*, o.name").
+		From(usersOrganizationsTableWithShortName).
+		Join(organizationsTable + " as o on uo.organization_id = o.id").
+		Where(sqrl.Eq{"uo.user_id": userID}).
+		OrderBy("o.name").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var usersOrganizations []*entities.UsersOrganization
+	err := r.executor.Run(ctx, &usersOrganizations, q)
+	return usersOrganizations, err
+}
+
+func (r *organizationsRepository) GetOrganizationsUsers(ctx context.Context, organizationID int64) ([]*entities.OrganizationsUser, error) {
+	q := sqrl.Select("u.*, uo.role").
+		From(usersOrganizationsTableWithShortName).
+		Join(usersTableWithShortName+" on uo.user_id = u.id").
+		Where(sqrl.Eq{"uo.organization_id": organizationID, "u.deleted_at": nil}).
+		OrderBy("u.name", "u.id").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var organizationsUsers []*entities.OrganizationsUser
+	err := r.executor.Run(ctx, &organizationsUsers, q)
+	return organizationsUsers, err
+}
+
+func (r *organizationsRepository) CountOtherAdmins(ctx context.Context, organizationID, userID int64) (int64, error) {
+	q := sqrl.Select("count(*)").
+		From(usersOrganizationsTableWithShortName).
+		Join(usersTableWithShortName + " on uo.user_id = u.id").
+		Where(sqrl.Eq{
+			"uo.organization_id": organizationID,
+			"uo.role":            entities.OrganizationRoleAdmin,
+			"u.deleted_at":       nil,
+		}).
+		Where(sqrl.NotEq{"uo.user_id": userID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	var count int64
+	err := r.executor.Run(ctx, &count, q)
+	return count, err
+}
+
+func (r *organizationsRepository) IsDatabaseOrganizationAdmin(ctx context.Context, userID, databaseID int64, withDeleted bool) (bool, error) {
+	q := sqrl.Select("count(*) > 0").
+		From(usersOrganizationsTableWithShortName).
+		Join(databasesTableWithShortName + " on db.organization_id = uo.organization_id").
+		Where(sqrl.Eq{
+			"uo.user_id": userID,
+			"uo.role":    entities.OrganizationRoleAdmin,
+			"db.id":      databaseID,
+		}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	if !withDeleted {
+		q = q.Where(sqrl.Eq{"db.deleted_at": nil})
+	}
+
+	var isAdmin bool
+	err := r.executor.Run(ctx, &isAdmin, q)
+	return isAdmin, err
+}
+
+func (r *organizationsRepository) GetAdministeredDatabases(ctx context.Context, userID int64, deleted bool) ([]*entities.Database, error) {
+	q := sqrl.Select("db.*").
+		From(databasesTableWithShortName).
+		Join(usersOrganizationsTableWithShortName + " on uo.organization_id = db.organization_id").
+		Where(sqrl.Eq{
+			"uo.user_id": userID,
+			"uo.role":    entities.OrganizationRoleAdmin,
+		}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	if deleted {
+		q = q.Where(sqrl.NotEq{"db.deleted_at": nil})
+	} else {
+		q = q.Where(sqrl.Eq{"db.deleted_at": nil})
+	}
+
+	var databases []*entities.Database
+	err := r.executor.Run(ctx, &databases, q)
+	return databases, err
+}
+
+func (r *organizationsRepository) GetOrganizationDatabases(ctx context.Context, organizationID int64) ([]*entities.Database, error) {
+	q := sqrl.Select("*").
+		From(databasesTable).
+		Where(sqrl.Eq{"organization_id": organizationID, "deleted_at": nil}).
+		OrderBy("name").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var databases []*entities.Database
+	err := r.executor.Run(ctx, &databases, q)
+	return databases, err
+}
+
+func (r *organizationsRepository) SetDatabaseOrganization(ctx context.Context, databaseID int64, organizationID *int64) error {
+	q := sqrl.Update(databasesTable).
+		Set("organization_id", organizationID).
+		Where(sqrl.Eq{"id": databaseID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *organizationsRepository) GrantMembersDatabaseRole(
+	ctx context.Context,
+	organizationID, databaseID int64,
+	role entities.Role,
+) ([]int64, error) {
+	members := sqrl.Select("uo.user_id").
+		Column("?::integer", databaseID).
+		Column("?::text", role).
+		From(usersOrganizationsTableWithShortName).
+		Join(usersTableWithShortName + " on uo.user_id = u.id").
+		Where(sqrl.Eq{"uo.organization_id": organizationID, "u.deleted_at": nil})
+
+	// Removed members are soft-deleted, so they are brought back instead of being skipped.
+	q := sqrl.Insert(usersDatabasesTable).
+		Columns("user_id, database_id, role").
+		Select(members).
+		Suffix(`ON CONFLICT on constraint users_databases_pkey do update SET
+role = EXCLUDED.role,
+deleted_at = null
+WHERE users_databases.deleted_at is not null RETURNING user_id`).
+		PlaceholderFormat(sqrl.Dollar)
+
+	var ids []int64
+	err := r.executor.Run(ctx, &ids, q)
+	return ids, err
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+type personalAccessTokensRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewPersonalAccessTokensRepository(executor sql_executor.ISQLExecutor) IPersonalAccessTokensRepository {
+	return &personalAccessTokensRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *personalAccessTokensRepository) CreateToken(ctx context.Context, token *entities.PersonalAccessToken) (*entities.PersonalAccessToken, error) {
+	q := sqrl.Insert(personalAccessTokensTable).
+		Columns("user_id, name, token_hash, token_prefix, database_ids, access, expires_at").
+		Values(token.UserID, token.Name, token.TokenHash, token.TokenPrefix, token.DatabaseIDs, token.Access, token.ExpiresAt).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	createdToken := &entities.PersonalAccessToken{}
+	err := r.executor.Run(ctx, createdToken, q)
+	if err != nil {
+		return nil, err
+	}
+	return createdToken, nil
+}
+
+func (r *personalAccessTokensRepository) GetTokenByHash(ctx context.Context, hash string) (*entities.PersonalAccessToken, error) {
+	q := sqrl.Select("*").
+		From(personalAccessTokensTable).
+		Where(sqrl.Eq{"token_hash": hash}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	token := &entities.PersonalAccessToken{}
+	err := r.executor.Run(ctx, token, q)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *personalAccessTokensRepository) ListUserTokens(ctx context.Context, userID int64) ([]*entities.PersonalAccessToken, error) {
+	q := sqrl.Select("*").
+		From(personalAccessTokensTable).
+		Where(sqrl.Eq{"user_id": userID, "revoked_at": nil}).
+		OrderBy("created_at DESC").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var tokens []*entities.PersonalAccessToken
+	err := r.executor.Run(ctx, &tokens, q)
+	return tokens, err
+}
+
+func (r *personalAccessTokensRepository) TouchToken(ctx context.Context, id int64, usedAt time.Time) error {
+	q := sqrl.Update(personalAccessTokensTable).
+		Set("last_used_at", usedAt).
+		Where(sqrl.Eq{"id": id}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *personalAccessTokensRepository) RevokeToken(ctx context.Context, userID int64, id int64) (bool, error) {
+	q := sqrl.Update(personalAccessTokensTable).
+		Set("revoked_at", time.Now()).
+		Where(sqrl.Eq{"id": id, "user_id": userID, "revoked_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	res, err := r.executor.Exec(ctx, q)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *personalAccessTokensRepository) RevokeUserTokens(ctx context.Context, userID int64) error {
+	q := sqrl.Update(personalAccessTokensTable).
+		Set("revoked_at", time.Now()).
+		Where(sqrl.Eq{"user_id": userID, "revoked_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
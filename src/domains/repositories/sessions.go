@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+type sessionsRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewSessionsRepository(executor sql_executor.ISQLExecutor) ISessionsRepository {
+	return &sessionsRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *sessionsRepository) CreateSession(ctx context.Context, session *entities.Session) (*entities.Session, error) {
+	q := sqrl.Insert(sessionsTable).
+		Columns("id, user_id, refresh_token_hash, user_agent, ip, expires_at").
+		Values(session.ID, session.UserID, session.RefreshTokenHash, session.UserAgent, session.IP, session.ExpiresAt).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	createdSession := &entities.Session{}
+	err := r.executor.Run(ctx, createdSession, q)
+	if err != nil {
+		return nil, err
+	}
+	return createdSession, nil
+}
+
+func (r *sessionsRepository) GetSessionByID(ctx context.Context, id string) (*entities.Session, error) {
+	q := sqrl.Select("*").
+		From(sessionsTable).
+		Where(sqrl.Eq{"id": id}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	session := &entities.Session{}
+	err := r.executor.Run(ctx, session, q)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *sessionsRepository) GetSessionByRefreshTokenHash(ctx context.Context, hash string) (*entities.Session, error) {
+	q := sqrl.Select("*").
+		From(sessionsTable).
+		Where(sqrl.Or{
+			sqrl.Eq{"refresh_token_hash": hash},
+			sqrl.Eq{"previous_refresh_token_hash": hash},
+		}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	session := &entities.Session{}
+	err := r.executor.Run(ctx, session, q)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *sessionsRepository) RotateRefreshToken(
+	ctx context.Context,
+	id string,
+	oldHash string,
+	newHash string,
+	expiresAt time.Time,
+	client entities.SessionClient,
+) (*entities.Session, error) {
+	q := sqrl.Update(sessionsTable).
+		Set("refresh_token_hash", newHash).
+		Set("previous_refresh_token_hash", oldHash).
+		Set("expires_at", expiresAt).
+		Set("last_used_at", time.Now()).
+		Set("user_agent", client.UserAgent).
+		Set("ip", client.IP).
+		Where(sqrl.Eq{"id": id, "refresh_token_hash": oldHash, "revoked_at": nil}).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	session := &entities.Session{}
+	err := r.executor.Run(ctx, session, q)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *sessionsRepository) RevokeSession(ctx context.Context, id string) error {
+	q := sqrl.Update(sessionsTable).
+		Set("revoked_at", time.Now()).
+		Where(sqrl.Eq{"id": id, "revoked_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *sessionsRepository) RevokeUserSessions(ctx context.Context, userID int64) error {
+	q := sqrl.Update(sessionsTable).
+		Set("revoked_at", time.Now()).
+		Where(sqrl.Eq{"user_id": userID, "revoked_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *sessionsRepository) RevokeOtherUserSessions(ctx context.Context, userID int64, keepSessionID string) error {
+	q := sqrl.Update(sessionsTable).
+		Set("revoked_at", time.Now()).
+		Where(sqrl.Eq{"user_id": userID, "revoked_at": nil}).
+		Where(sqrl.NotEq{"id": keepSessionID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *sessionsRepository) ListActiveUserSessions(ctx context.Context, userID int64) ([]*entities.Session, error) {
+	q := sqrl.Select("*").
+		From(sessionsTable).
+		Where(sqrl.And{
+			sqrl.Eq{"user_id": userID},
+			sqrl.Eq{"revoked_at": nil},
+			sqrl.Expr("expires_at > now()"),
+		}).
+		OrderBy("last_used_at DESC").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var sessions []*entities.Session
+	err := r.executor.Run(ctx, &sessions, q)
+	return sessions, err
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+type tableShareLinksRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewTableShareLinksRepository(executor sql_executor.ISQLExecutor) ITableShareLinksRepository {
+	return &tableShareLinksRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *tableShareLinksRepository) CreateLink(ctx context.Context, link *entities.TableShareLink) (*entities.TableShareLink, error) {
+	q := sqrl.Insert(tableShareLinksTable).
+		Columns("table_id, created_by, token_hash, token_prefix, password_hash, column_ids, live, expires_at").
+		Values(link.TableID, link.CreatedBy, link.TokenHash, link.TokenPrefix, link.PasswordHash, link.ColumnIDs, link.Live, link.ExpiresAt).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	createdLink := &entities.TableShareLink{}
+	err := r.executor.Run(ctx, createdLink, q)
+	if err != nil {
+		return nil, err
+	}
+	return createdLink, nil
+}
+
+func (r *tableShareLinksRepository) GetLinkByHash(ctx context.Context, hash string) (*entities.TableShareLink, error) {
+	q := sqrl.Select("*").
+		From(tableShareLinksTable).
+		Where(sqrl.Eq{"token_hash": hash}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	link := &entities.TableShareLink{}
+	err := r.executor.Run(ctx, link, q)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (r *tableShareLinksRepository) ListTableLinks(ctx context.Context, tableID string) ([]*entities.TableShareLink, error) {
+	q := sqrl.Select("*").
+		From(tableShareLinksTable).
+		Where(sqrl.Eq{"table_id": tableID, "revoked_at": nil}).
+		OrderBy("created_at DESC").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var links []*entities.TableShareLink
+	err := r.executor.Run(ctx, &links, q)
+	return links, err
+}
+
+func (r *tableShareLinksRepository) RevokeLink(ctx context.Context, tableID string, id int64) (bool, error) {
+	q := sqrl.Update(tableShareLinksTable).
+		Set("revoked_at", time.Now()).
+		Where(sqrl.Eq{"id": id, "table_id": tableID, "revoked_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	res, err := r.executor.Exec(ctx, q)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
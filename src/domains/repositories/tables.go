@@ -5,6 +5,7 @@ import (
 	"backend/src/modules/sql_executor"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/elgris/sqrl"
@@ -25,8 +26,8 @@ func NewTablesRepository(executor sql_executor.ISQLExecutor) ITablesRepository {
 func (r *tablesRepository) AddTable(ctx context.Context, table *entities.Table) (*entities.Table, error) {
 	dbTable := table.ToDBTable()
 	q := sqrl.Insert(tablesTable).
-		Columns("id, name, database_id, columns").
-		Values(dbTable.ID, dbTable.Name, dbTable.DatabaseID, dbTable.Columns).
+		Columns("id, name, database_id, columns, row_policies").
+		Values(dbTable.ID, dbTable.Name, dbTable.DatabaseID, dbTable.Columns, dbTable.RowPolicies).
 		PlaceholderFormat(sqrl.Dollar).
 		Returning("*")
 
@@ -81,6 +82,7 @@ func (r *tablesRepository) UpdateTable(ctx context.Context, table *entities.Tabl
 	q := sqrl.Update(tablesTable).
 		Set("name", dbTable.Name).
 		Set("columns", dbTable.Columns).
+		Set("row_policies", dbTable.RowPolicies).
 		Set("database_id", dbTable.DatabaseID).
 		Where(sqrl.Eq{"id": table.ID}).
 		PlaceholderFormat(sqrl.Dollar)
@@ -89,6 +91,33 @@ func (r *tablesRepository) UpdateTable(ctx context.Context, table *entities.Tabl
 	return err
 }
 
+func (r *tablesRepository) CopyRows(ctx context.Context, source, target *entities.Table, columnIDs map[string]string) error {
+	sourceCols := []string{"id", "sort_index", "sort_index_version", "created_by", "created_at", "updated_by", "updated_at", "deleted_at"}
+	targetCols := append([]string{}, sourceCols...)
+	for sourceID, targetID := range columnIDs {
+		sourceCols = append(sourceCols, sourceID)
+		targetCols = append(targetCols, targetID)
+	}
+
+	_, err := r.executor.Exec(ctx, sqrl.Expr(fmt.Sprintf(
+		"insert into %s.%s (%s) select %s from %s.%s",
+		entities.UsersTablespace, target.ID, strings.Join(targetCols, ", "),
+		strings.Join(sourceCols, ", "), entities.UsersTablespace, source.ID,
+	)))
+	if err != nil {
+		return err
+	}
+
+	// rows keep their IDs, so the sequences have to continue after them
+	targetTable := fmt.Sprintf("%s.%s", entities.UsersTablespace, target.ID)
+	_, err = r.executor.Exec(ctx, sqrl.Expr(fmt.Sprintf(
+		"select setval(pg_get_serial_sequence('%[1]s', 'id'), coalesce(max(id), 0) + 1, false), "+
+			"setval(pg_get_serial_sequence('%[1]s', 'sort_index'), coalesce(max(sort_index), 0) + 1, false) from %[1]s",
+		targetTable,
+	)))
+	return err
+}
+
 func (r *tablesRepository) ListByDatabaseID(ctx context.Context, databaseID int64) ([]*entities.Table, error) {
 	q := sqrl.Select("*").
 		From(tablesTable).
@@ -136,9 +165,9 @@ func (r *tablesRepository) ListByDatabaseIDs(ctx context.Context, databaseIDs []
 	return tables, err
 }
 
-func (r *tablesRepository) AddRow(ctx context.Context, table *entities.Table, data map[string]*string, sortIndex *int64) (entities.TableRow, error) {
-	cols := []string{"sort_index_version"}
-	values := []interface{}{time.Now().UnixNano()}
+func (r *tablesRepository) AddRow(ctx context.Context, table *entities.Table, createdBy int64, data map[string]*string, sortIndex *int64) (entities.TableRow, error) {
+	cols := []string{"sort_index_version", "created_by", "updated_by"}
+	values := []interface{}{time.Now().UnixNano(), createdBy, createdBy}
 	if sortIndex != nil {
 		cols = append(cols, "sort_index")
 		values = append(values, *sortIndex)
@@ -194,9 +223,11 @@ func (r *tablesRepository) MoveRow(ctx context.Context, tableID string, rowID in
 	return err
 }
 
-func (r *tablesRepository) SetCellValue(ctx context.Context, tableID string, rowID int64, columnID string, value *string) (*entities.RawCellChangeInfo, error) {
+func (r *tablesRepository) SetCellValue(ctx context.Context, tableID string, rowID int64, columnID string, value *string, updatedBy int64) (*entities.RawCellChangeInfo, error) {
 	q := sqrl.Update(fmt.Sprintf("%s.%s as t", entities.UsersTablespace, tableID)).
 		Set(columnID, value).
+		Set("updated_at", sqrl.Expr("now()")).
+		Set("updated_by", updatedBy).
 		From("old_data").
 		Where(sqrl.Eq{"t.id": rowID}).
 		PlaceholderFormat(sqrl.Dollar).
@@ -215,9 +246,13 @@ func (r *tablesRepository) ReadTable(ctx context.Context, table *entities.Table,
 		Where(sqrl.Eq{"deleted_at": nil}).
 		PlaceholderFormat(sqrl.Dollar)
 
+	if rowFilter := table.RowFilter(); rowFilter != nil {
+		q = q.Where(rowFilter)
+	}
+
 	orderBys := make([]string, 0, 3)
 	if params != nil {
-		if ok, filter, filterValue := params.GetFilter(); ok {
+		if ok, filter, filterValue := params.GetFilter(table); ok {
 			q = q.Where(sqrl.Expr(filter, filterValue))
 		}
 
@@ -246,8 +281,12 @@ func (r *tablesRepository) GetTotalRows(ctx context.Context, table *entities.Tab
 		Where(sqrl.Eq{"deleted_at": nil}).
 		PlaceholderFormat(sqrl.Dollar)
 
+	if rowFilter := table.RowFilter(); rowFilter != nil {
+		q = q.Where(rowFilter)
+	}
+
 	if params != nil {
-		if ok, filter, filterValue := params.GetFilter(); ok {
+		if ok, filter, filterValue := params.GetFilter(table); ok {
 			q = q.Where(sqrl.Expr(filter, filterValue))
 		}
 
@@ -263,9 +302,65 @@ func (r *tablesRepository) GetTotalRows(ctx context.Context, table *entities.Tab
 	return dest.Total, err
 }
 
-func (r *tablesRepository) AddRows(ctx context.Context, table *entities.Table, data []map[string]*string) error {
-	cols := make([]string, 0, len(table.Columns)+1)
-	cols = append(cols, "sort_index_version")
+func (r *tablesRepository) IsRowAccessible(ctx context.Context, table *entities.Table, rowID int64) (bool, error) {
+	q := sqrl.Select("count(*) as total").
+		From(fmt.Sprintf("%s.%s", entities.UsersTablespace, table.ID)).
+		Where(sqrl.Eq{"id": rowID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	if rowFilter := table.RowFilter(); rowFilter != nil {
+		q = q.Where(rowFilter)
+	}
+
+	var dest struct {
+		Total int64 `db:"total"`
+	}
+	err := r.executor.Run(ctx, &dest, q)
+	return dest.Total > 0, err
+}
+
+func (r *tablesRepository) FilterAccessibleRowIDs(ctx context.Context, table *entities.Table, ids []int64) ([]int64, error) {
+	q := sqrl.Select("id").
+		From(fmt.Sprintf("%s.%s", entities.UsersTablespace, table.ID)).
+		Where(sqrl.Eq{"id": ids}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	if rowFilter := table.RowFilter(); rowFilter != nil {
+		q = q.Where(rowFilter)
+	}
+
+	var dest []struct {
+		ID int64 `db:"id"`
+	}
+	if err := r.executor.Run(ctx, &dest, q); err != nil {
+		return nil, err
+	}
+
+	res := make([]int64, 0, len(dest))
+	for _, row := range dest {
+		res = append(res, row.ID)
+	}
+	return res, nil
+}
+
+func (r *tablesRepository) GetRowsByIDs(ctx context.Context, table *entities.Table, ids []int64) ([]entities.TableRow, error) {
+	q := sqrl.Select(table.ReturningCols()...).
+		From(fmt.Sprintf("%s.%s", entities.UsersTablespace, table.ID)).
+		Where(sqrl.Eq{"id": ids, "deleted_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	if rowFilter := table.RowFilter(); rowFilter != nil {
+		q = q.Where(rowFilter)
+	}
+
+	var rows []entities.TableRow
+	err := r.executor.Run(ctx, &rows, q)
+	return rows, err
+}
+
+func (r *tablesRepository) AddRows(ctx context.Context, table *entities.Table, createdBy int64, data []map[string]*string) error {
+	cols := make([]string, 0, len(table.Columns)+3)
+	cols = append(cols, "sort_index_version", "created_by", "updated_by")
 	for _, col := range table.Columns {
 		if col.DeletedAt != nil {
 			continue
@@ -279,7 +374,7 @@ func (r *tablesRepository) AddRows(ctx context.Context, table *entities.Table, d
 	now := time.Now().UnixNano()
 	for _, rowData := range data {
 		values := make([]interface{}, 0, len(cols))
-		values = append(values, now)
+		values = append(values, now, createdBy, createdBy)
 		for _, col := range table.Columns {
 			if col.DeletedAt != nil {
 				continue
@@ -295,9 +390,9 @@ func (r *tablesRepository) AddRows(ctx context.Context, table *entities.Table, d
 	return err
 }
 
-func (r *tablesRepository) AddFullFilledRows(ctx context.Context, table *entities.Table, rows [][]*string) error {
-	cols := make([]string, 0, len(table.Columns)+1)
-	cols = append(cols, "sort_index_version")
+func (r *tablesRepository) AddFullFilledRows(ctx context.Context, table *entities.Table, createdBy int64, rows [][]*string) error {
+	cols := make([]string, 0, len(table.Columns)+3)
+	cols = append(cols, "sort_index_version", "created_by", "updated_by")
 	for _, col := range table.Columns {
 		if col.DeletedAt != nil {
 			continue
@@ -311,7 +406,7 @@ func (r *tablesRepository) AddFullFilledRows(ctx context.Context, table *entitie
 	now := time.Now().UnixNano()
 	for _, row := range rows {
 		values := make([]interface{}, 0, len(cols))
-		values = append(values, now)
+		values = append(values, now, createdBy, createdBy)
 		for _, val := range row {
 			values = append(values, val)
 		}
@@ -334,3 +429,17 @@ func (r *tablesRepository) GetDistinctValues(ctx context.Context, tableID, colum
 	err := r.executor.Run(ctx, &values, q)
 	return values, err
 }
+
+func (r *tablesRepository) ReplaceColumnValues(ctx context.Context, tableID, columnID string, replacements map[string]string) error {
+	for from, to := range replacements {
+		q := sqrl.Update(fmt.Sprintf("%s.%s", entities.UsersTablespace, tableID)).
+			Set(columnID, to).
+			Where(sqrl.Eq{columnID: from}).
+			PlaceholderFormat(sqrl.Dollar)
+
+		if _, err := r.executor.Exec(ctx, q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
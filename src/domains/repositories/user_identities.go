@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+
+	"github.com/elgris/sqrl"
+)
+
+type userIdentitiesRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewUserIdentitiesRepository(executor sql_executor.ISQLExecutor) IUserIdentitiesRepository {
+	return &userIdentitiesRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *userIdentitiesRepository) CreateIdentity(ctx context.Context, identity *entities.UserIdentity) error {
+	q := sqrl.Insert(userIdentitiesTable).
+		Columns("issuer, subject, user_id, email").
+		Values(identity.Issuer, identity.Subject, identity.UserID, identity.Email).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *userIdentitiesRepository) GetIdentity(ctx context.Context, issuer, subject string) (*entities.UserIdentity, error) {
+	q := sqrl.Select("*").
+		From(userIdentitiesTable).
+		Where(sqrl.Eq{"issuer": issuer, "subject": subject}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	identity := &entities.UserIdentity{}
+	err := r.executor.Run(ctx, identity, q)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (r *userIdentitiesRepository) DeleteUserIdentities(ctx context.Context, userID int64) error {
+	q := sqrl.Delete(userIdentitiesTable).
+		Where(sqrl.Eq{"user_id": userID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+type userTokensRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewUserTokensRepository(executor sql_executor.ISQLExecutor) IUserTokensRepository {
+	return &userTokensRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *userTokensRepository) CreateToken(ctx context.Context, token *entities.UserToken) (*entities.UserToken, error) {
+	q := sqrl.Insert(userTokensTable).
+		Columns("user_id, purpose, token_hash, email, expires_at").
+		Values(token.UserID, token.Purpose, token.TokenHash, token.Email, token.ExpiresAt).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	createdToken := &entities.UserToken{}
+	err := r.executor.Run(ctx, createdToken, q)
+	if err != nil {
+		return nil, err
+	}
+	return createdToken, nil
+}
+
+func (r *userTokensRepository) ConsumeToken(ctx context.Context, purpose entities.UserTokenPurpose, hash string) (*entities.UserToken, error) {
+	now := time.Now()
+	q := sqrl.Update(userTokensTable).
+		Set("used_at", now).
+		Where(sqrl.Eq{"purpose": purpose, "token_hash": hash, "used_at": nil}).
+		Where(sqrl.Gt{"expires_at": now}).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	token := &entities.UserToken{}
+	err := r.executor.Run(ctx, token, q)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *userTokensRepository) InvalidateUserTokens(ctx context.Context, userID int64, purpose entities.UserTokenPurpose) error {
+	q := sqrl.Update(userTokensTable).
+		Set("used_at", time.Now()).
+		Where(sqrl.Eq{"user_id": userID, "purpose": purpose, "used_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
@@ -4,8 +4,12 @@ import (
 	"backend/src/domains/entities"
 	"backend/src/modules/sql_executor"
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/elgris/sqrl"
+	"github.com/lib/pq"
 )
 
 type usersRepository struct {
@@ -54,14 +58,140 @@ func (r *usersRepository) GetUserByID(ctx context.Context, id int64) (*entities.
 	return &user, err
 }
 
-func (r *usersRepository) ListUsers(ctx context.Context) ([]*entities.User, error) {
-	q := sqrl.Select("*").
-		From(usersTable).
-		Where(sqrl.Eq{"deleted_at": nil}).
-		OrderBy("name").
+func (r *usersRepository) UpdateUserPassword(ctx context.Context, id int64, password string) error {
+	q := sqrl.Update(usersTable).
+		Set("password", password).
+		Set("updated_at", time.Now()).
+		Where(sqrl.Eq{"id": id}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *usersRepository) MarkEmailVerified(ctx context.Context, id int64, email string) (bool, error) {
+	q := sqrl.Update(usersTable).
+		Set("email_verified_at", time.Now()).
+		Set("updated_at", time.Now()).
+		Where(sqrl.Eq{"id": id, "email": email, "deleted_at": nil}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	res, err := r.executor.Exec(ctx, q)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *usersRepository) UpdateUserName(ctx context.Context, id int64, name string) (*entities.User, error) {
+	q := sqrl.Update(usersTable).
+		Set("name", name).
+		Set("updated_at", time.Now()).
+		Where(sqrl.Eq{"id": id, "deleted_at": nil}).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	var user entities.User
+	err := r.executor.Run(ctx, &user, q)
+	return &user, err
+}
+
+func (r *usersRepository) UpdateUserEmail(ctx context.Context, id int64, email string) (*entities.User, error) {
+	now := time.Now()
+	q := sqrl.Update(usersTable).
+		Set("email", email).
+		Set("email_verified_at", now).
+		Set("updated_at", now).
+		Where(sqrl.Eq{"id": id, "deleted_at": nil}).
+		PlaceholderFormat(sqrl.Dollar).
+		Returning("*")
+
+	var user entities.User
+	err := r.executor.Run(ctx, &user, q)
+	return &user, err
+}
+
+func (r *usersRepository) DeleteUser(ctx context.Context, id int64) error {
+	now := time.Now()
+	q := sqrl.Update(usersTable).
+		Set("deleted_at", now).
+		Set("updated_at", now).
+		Where(sqrl.Eq{"id": id, "deleted_at": nil}).
 		PlaceholderFormat(sqrl.Dollar)
 
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *usersRepository) SearchUsers(ctx context.Context, callerID int64, params entities.SearchUsersParams) ([]*entities.User, error) {
+	query := strings.TrimSpace(params.Query)
+	q := sqrl.Select("u.*").
+		From(usersTableWithShortName).
+		Where(searchUsersCondition(callerID, query)).
+		Limit(uint64(params.GetLimit())).
+		Offset(uint64(params.GetOffset())).
+		PlaceholderFormat(sqrl.Dollar)
+
+	if query != "" {
+		// sqrl can't bind arguments in ORDER BY, so the query is inlined as a quoted literal,
+		// with ? doubled for sqrl not to take it for a placeholder.
+		literal := strings.ReplaceAll(pq.QuoteLiteral(query), "?", "??")
+		q = q.OrderBy(
+			fmt.Sprintf("lower(u.email) = lower(%s) desc", literal),
+			fmt.Sprintf("greatest(similarity(u.name, %s), similarity(u.email, %s)) desc", literal, literal),
+		)
+	}
+	q = q.OrderBy("u.name", "u.id")
+
 	var users []*entities.User
 	err := r.executor.Run(ctx, &users, q)
 	return users, err
 }
+
+func (r *usersRepository) GetTotalSearchUsers(ctx context.Context, callerID int64, params entities.SearchUsersParams) (int64, error) {
+	q := sqrl.Select("count(*)").
+		From(usersTableWithShortName).
+		Where(searchUsersCondition(callerID, strings.TrimSpace(params.Query))).
+		PlaceholderFormat(sqrl.Dollar)
+
+	var total int64
+	err := r.executor.Run(ctx, &total, q)
+	return total, err
+}
+
+func searchUsersCondition(callerID int64, query string) sqrl.Sqlizer {
+	sharesDatabase := sqrl.Expr(`exists (
+select 1 from app.users_databases as mine
+join app.users_databases as theirs on theirs.database_id = mine.database_id
+join app.databases as sdb on sdb.id = mine.database_id
+where mine.user_id = ? and theirs.user_id = u.id
+and mine.deleted_at is null and theirs.deleted_at is null and sdb.deleted_at is null)`, callerID)
+
+	visible := sqrl.Or{sharesDatabase}
+	if query != "" {
+		pattern := "%" + escapeLike(query) + "%"
+		visible = sqrl.Or{
+			// Exact email matches are visible to everybody, so that strangers can be invited.
+			sqrl.Expr("lower(u.email) = lower(?)", query),
+			sqrl.And{
+				sharesDatabase,
+				sqrl.Or{
+					sqrl.Expr("u.name % ?", query),
+					sqrl.Expr("u.email % ?", query),
+					sqrl.Expr("u.name ILIKE ?", pattern),
+					sqrl.Expr("u.email ILIKE ?", pattern),
+				},
+			},
+		}
+	}
+
+	return sqrl.And{
+		sqrl.Eq{"u.deleted_at": nil},
+		visible,
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
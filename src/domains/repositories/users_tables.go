@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/sql_executor"
+	"context"
+
+	"github.com/elgris/sqrl"
+)
+
+type usersTablesRepository struct {
+	ICommonRepository
+	executor sql_executor.ISQLExecutor
+}
+
+func NewUsersTablesRepository(executor sql_executor.ISQLExecutor) IUsersTablesRepository {
+	return &usersTablesRepository{
+		ICommonRepository: NewCommonRepository(),
+		executor:          executor,
+	}
+}
+
+func (r *usersTablesRepository) UpsertUsersTable(ctx context.Context, usersTable *entities.UsersTable) (*entities.UsersTable, error) {
+	q := sqrl.Insert(usersTablesTable).
+		Columns("user_id, table_id, role").
+		Values(usersTable.UserID, usersTable.TableID, usersTable.Role).
+		PlaceholderFormat(sqrl.Dollar).
+		Suffix("ON CONFLICT (user_id, table_id) DO UPDATE SET role = EXCLUDED.role, updated_at = now() RETURNING *")
+
+	upsertedUsersTable := &entities.UsersTable{}
+	err := r.executor.Run(ctx, upsertedUsersTable, q)
+	if err != nil {
+		return nil, err
+	}
+	return upsertedUsersTable, nil
+}
+
+func (r *usersTablesRepository) DeleteUsersTable(ctx context.Context, userID int64, tableID string) error {
+	q := sqrl.Delete(usersTablesTable).
+		Where(sqrl.Eq{"user_id": userID, "table_id": tableID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *usersTablesRepository) DeleteUsersDatabaseTables(ctx context.Context, userID, databaseID int64) error {
+	q := sqrl.Delete(usersTablesTable).
+		Where(sqrl.Eq{"user_id": userID}).
+		Where("table_id in (select id from "+tablesTable+" where database_id = ?)", databaseID).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *usersTablesRepository) DeleteTableUsers(ctx context.Context, tableID string) error {
+	q := sqrl.Delete(usersTablesTable).
+		Where(sqrl.Eq{"table_id": tableID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := r.executor.Exec(ctx, q)
+	return err
+}
+
+func (r *usersTablesRepository) GetUsersTableRole(ctx context.Context, userID int64, tableID string) (entities.Role, error) {
+	q := sqrl.Select("role").
+		From(usersTablesTable).
+		Where(sqrl.Eq{"user_id": userID, "table_id": tableID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	var role entities.Role
+	err := r.executor.Run(ctx, &role, q)
+	return role, err
+}
+
+func (r *usersTablesRepository) GetUsersTables(ctx context.Context, userID int64) ([]*entities.UsersTable, error) {
+	q := sqrl.Select("*").
+		From(usersTablesTable).
+		Where(sqrl.Eq{"user_id": userID}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	var usersTables []*entities.UsersTable
+	err := r.executor.Run(ctx, &usersTables, q)
+	return usersTables, err
+}
+
+func (r *usersTablesRepository) GetTablesUsers(ctx context.Context, tableID string) ([]*entities.TablesUser, error) {
+	q := sqrl.Select("u.*, ut.role").
+		From(usersTablesTableWithShortName).
+		Join(usersTableWithShortName + " on ut.user_id = u.id").
+		Where(sqrl.Eq{"ut.table_id": tableID, "u.deleted_at": nil}).
+		OrderBy("u.name").
+		PlaceholderFormat(sqrl.Dollar)
+
+	var tablesUsers []*entities.TablesUser
+	err := r.executor.Run(ctx, &tablesUsers, q)
+	return tablesUsers, err
+}
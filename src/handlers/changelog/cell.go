@@ -45,13 +45,30 @@ func (h *cellHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), table.DatabaseID, entities.RoleReader)
+	userID := c.MustGet("user_id").(int64)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have reader role"})
+	if !permissions.Has(entities.PermissionRead) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have read permission"})
+		return
+	}
+
+	table = table.ForViewer(entities.TableViewer{UserID: userID, Role: role})
+	if !table.HasColumn(req.ColumnID) {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "column not found"})
+		return
+	}
+
+	accessible, err := h.tablesService.IsRowAccessible(c, table, req.RowID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !accessible {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "row not found"})
 		return
 	}
 
@@ -0,0 +1,63 @@
+package changelog
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type databaseHandler struct {
+	changelogService services.IChangelogService
+	databasesService services.IDatabasesService
+}
+
+func newDatabaseHandler(
+	changelogService services.IChangelogService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &databaseHandler{
+		changelogService: changelogService,
+		databasesService: databasesService,
+	}
+}
+
+func (h *databaseHandler) Handle(c *gin.Context) {
+	req := databaseRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), req.DatabaseID, entities.RoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	changelog, err := h.changelogService.ListChangelogForDatabase(c, req.DatabaseID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newDatabaseChangelogResponse(changelog))
+}
+
+func (h *databaseHandler) Path() string {
+	return "/changelog/database"
+}
+
+func (h *databaseHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *databaseHandler) AuthRequired() bool {
+	return true
+}
@@ -13,5 +13,6 @@ func NewHandlers(
 	return []handlers.IHandler{
 		newCellHandler(changelogService, tablesService, databasesService),
 		newTableHandler(changelogService, tablesService, databasesService),
+		newDatabaseHandler(changelogService, databasesService),
 	}
 }
@@ -9,3 +9,7 @@ type cellRequestDto struct {
 type tableRequestDto struct {
 	TableID string `json:"table_id" binding:"required"`
 }
+
+type databaseRequestDto struct {
+	DatabaseID int64 `json:"database_id" binding:"required"`
+}
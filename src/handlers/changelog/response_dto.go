@@ -38,10 +38,11 @@ func newCellChangelogResponse(changelog []*entities.ChangelogItemWithUserInfo) c
 
 type tableChangelogResponse []*tableChangelogItemResponse
 
-func newTableChangelogResponse(changelog []*entities.ChangelogItemWithUserInfo) tableChangelogResponse {
+// newTableChangelogResponse leaves out changes of the columns missing in the table view.
+func newTableChangelogResponse(changelog []*entities.ChangelogItemWithUserInfo, table *entities.Table) tableChangelogResponse {
 	res := make(tableChangelogResponse, 0, len(changelog))
 	for _, item := range changelog {
-		if logItem := newTableChangelogItemResponse(item); logItem != nil {
+		if logItem := newTableChangelogItemResponse(item, table); logItem != nil {
 			res = append(res, logItem)
 		}
 	}
@@ -60,7 +61,7 @@ type tableChangelogItemResponse struct {
 	User          *common.UserInfoResponse  `json:"user"`
 }
 
-func newTableChangelogItemResponse(item *entities.ChangelogItemWithUserInfo) *tableChangelogItemResponse {
+func newTableChangelogItemResponse(item *entities.ChangelogItemWithUserInfo, table *entities.Table) *tableChangelogItemResponse {
 	change := item.Change.Get()
 	res := &tableChangelogItemResponse{
 		ChangeID:      item.ChangeID,
@@ -72,9 +73,12 @@ func newTableChangelogItemResponse(item *entities.ChangelogItemWithUserInfo) *ta
 	switch change.ChangedEntity {
 	case entities.ChangedEntityRow:
 		res.ChangeType = change.RowChange.ChangeType
-		res.BeforeRow = newRowForChangelog(change.RowChange.Before)
-		res.AfterRow = newRowForChangelog(change.RowChange.After)
+		res.BeforeRow = newRowForChangelog(change.RowChange.Before, table)
+		res.AfterRow = newRowForChangelog(change.RowChange.After, table)
 	case entities.ChangedEntityColumn:
+		if item.ColumnID == nil || !table.HasColumn(*item.ColumnID) {
+			return nil
+		}
 		res.ChangeType = change.ColumnChange.ChangeType
 		if change.ColumnChange.Before != nil {
 			res.BeforeColumn = pointer.To(common.NewColumnForResponse(change.ColumnChange.Before))
@@ -98,13 +102,16 @@ type rowItemInfoForChangelog struct {
 	Value      any
 }
 
-func newRowForChangelog(row entities.RowInfoForChangelog) rowForChangelog {
+func newRowForChangelog(row entities.RowInfoForChangelog, table *entities.Table) rowForChangelog {
 	if len(row) == 0 {
 		return nil
 	}
 
 	res := make(rowForChangelog, 0, len(row))
 	for _, item := range row {
+		if !table.HasColumn(item.ColumnID) {
+			continue
+		}
 		res = append(res, &rowItemInfoForChangelog{
 			ColumnID:   item.ColumnID,
 			ColumnName: item.ColumnName,
@@ -113,3 +120,63 @@ func newRowForChangelog(row entities.RowInfoForChangelog) rowForChangelog {
 	}
 	return res
 }
+
+type databaseChangelogResponse []*databaseChangelogItemResponse
+
+func newDatabaseChangelogResponse(changelog []*entities.ChangelogItemWithUserInfo) databaseChangelogResponse {
+	res := make(databaseChangelogResponse, 0, len(changelog))
+	for _, item := range changelog {
+		if logItem := newDatabaseChangelogItemResponse(item); logItem != nil {
+			res = append(res, logItem)
+		}
+	}
+	return res
+}
+
+type databaseChangelogItemResponse struct {
+	ChangeID       int64                              `json:"change_id"`
+	ChangedEntity  entities.ChangedEntity             `json:"changed_entity"`
+	ChangeType     entities.ChangeType                `json:"change_type"`
+	BeforeDatabase *entities.DatabaseInfoForChangelog `json:"before_database,omitempty"`
+	AfterDatabase  *entities.DatabaseInfoForChangelog `json:"after_database,omitempty"`
+	MemberID       *int64                             `json:"member_id,omitempty"`
+	BeforeRole     entities.Role                      `json:"before_role,omitempty"`
+	AfterRole      entities.Role                      `json:"after_role,omitempty"`
+	TableID        *string                            `json:"table_id,omitempty"`
+	BeforeTable    *entities.TableInfoForChangelog    `json:"before_table,omitempty"`
+	AfterTable     *entities.TableInfoForChangelog    `json:"after_table,omitempty"`
+	ChangedAt      time.Time                          `json:"changed_at"`
+	User           *common.UserInfoResponse           `json:"user"`
+}
+
+func newDatabaseChangelogItemResponse(item *entities.ChangelogItemWithUserInfo) *databaseChangelogItemResponse {
+	change := item.Change.Get()
+	res := &databaseChangelogItemResponse{
+		ChangeID:      item.ChangeID,
+		ChangedEntity: change.ChangedEntity,
+		ChangedAt:     item.ChangedAt,
+		User:          common.NewUserInfoResponse(item.User),
+	}
+
+	switch change.ChangedEntity {
+	case entities.ChangedEntityDatabase:
+		res.ChangeType = change.DatabaseChange.ChangeType
+		res.BeforeDatabase = change.DatabaseChange.Before
+		res.AfterDatabase = change.DatabaseChange.After
+	case entities.ChangedEntityMember:
+		res.ChangeType = change.MemberChange.ChangeType
+		res.MemberID = pointer.To(change.MemberChange.MemberID)
+		res.BeforeRole = change.MemberChange.Before
+		res.AfterRole = change.MemberChange.After
+	case entities.ChangedEntityTable:
+		res.ChangeType = change.TableChange.ChangeType
+		res.TableID = item.TableID
+		res.BeforeTable = change.TableChange.Before
+		res.AfterTable = change.TableChange.After
+
+	default:
+		return nil
+	}
+
+	return res
+}
@@ -45,23 +45,75 @@ func (h *tableHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), table.DatabaseID, entities.RoleReader)
+	userID := c.MustGet("user_id").(int64)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have reader role"})
+	if !permissions.Has(entities.PermissionRead) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have read permission"})
 		return
 	}
 
+	table = table.ForViewer(entities.TableViewer{UserID: userID, Role: role})
+
 	changelog, err := h.changelogService.ListChangelogForTable(c, req.TableID)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, newTableChangelogResponse(changelog))
+	changelog, err = h.filterAccessibleRows(c, table, changelog)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTableChangelogResponse(changelog, table))
+}
+
+// filterAccessibleRows leaves out changes of the rows not passing the row policies of the table view.
+func (h *tableHandler) filterAccessibleRows(
+	c *gin.Context,
+	table *entities.Table,
+	changelog []*entities.ChangelogItemWithUserInfo,
+) ([]*entities.ChangelogItemWithUserInfo, error) {
+	if table.RowFilter() == nil {
+		return changelog, nil
+	}
+
+	rowIDs := make([]int64, 0, len(changelog))
+	seen := make(map[int64]struct{}, len(changelog))
+	for _, item := range changelog {
+		if item.RowID == nil {
+			continue
+		}
+		if _, ok := seen[*item.RowID]; !ok {
+			seen[*item.RowID] = struct{}{}
+			rowIDs = append(rowIDs, *item.RowID)
+		}
+	}
+
+	accessibleIDs, err := h.tablesService.FilterAccessibleRowIDs(c, table, rowIDs)
+	if err != nil {
+		return nil, err
+	}
+	accessible := make(map[int64]struct{}, len(accessibleIDs))
+	for _, id := range accessibleIDs {
+		accessible[id] = struct{}{}
+	}
+
+	res := make([]*entities.ChangelogItemWithUserInfo, 0, len(changelog))
+	for _, item := range changelog {
+		if item.RowID != nil {
+			if _, ok := accessible[*item.RowID]; !ok {
+				continue
+			}
+		}
+		res = append(res, item)
+	}
+	return res, nil
 }
 
 func (h *tableHandler) Path() string {
@@ -7,19 +7,27 @@ import (
 )
 
 type TableResponse struct {
-	ID         string              `json:"id"`
-	Name       string              `json:"name"`
-	DatabaseID int64               `json:"database_id"`
-	Columns    []ColumnForResponse `json:"columns"`
-	CreatedAt  time.Time           `json:"created_at"`
-	TotalRows  *int64              `json:"total_rows,omitempty"`
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	DatabaseID  int64                 `json:"database_id"`
+	Columns     []ColumnForResponse   `json:"columns"`
+	RowPolicies []*entities.RowPolicy `json:"row_policies,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	TotalRows   *int64                `json:"total_rows,omitempty"`
 }
 
 type ColumnForResponse struct {
-	Name string              `json:"name"`
-	Type entities.ColumnType `json:"type"`
-	ID   string              `json:"id"`
-	Enum []string            `json:"enum"`
+	Name       string                     `json:"name"`
+	Type       entities.ColumnType        `json:"type"`
+	ID         string                     `json:"id"`
+	Enum       []string                   `json:"enum"`
+	Policies   []*entities.ColumnPolicy   `json:"policies,omitempty"`
+	Link       *entities.ColumnLink       `json:"link,omitempty"`
+	Lookup     *entities.ColumnLookup     `json:"lookup,omitempty"`
+	Rollup     *entities.ColumnRollup     `json:"rollup,omitempty"`
+	Formula    *entities.ColumnFormula    `json:"formula,omitempty"`
+	Autonumber *entities.ColumnAutonumber `json:"autonumber,omitempty"`
+	ReadOnly   bool                       `json:"read_only"`
 }
 
 func NewTableResponse(table *entities.Table) *TableResponse {
@@ -28,23 +36,38 @@ func NewTableResponse(table *entities.Table) *TableResponse {
 		if col.DeletedAt != nil {
 			continue
 		}
-		cols = append(cols, NewColumnForResponse(col))
+		column := NewColumnForResponse(col)
+		if col.Formula != nil {
+			column.Formula = &entities.ColumnFormula{
+				Expression: table.FormulaWithNames(col),
+				ResultType: col.Formula.ResultType,
+			}
+		}
+		cols = append(cols, column)
 	}
 	return &TableResponse{
-		ID:         table.ID,
-		Name:       table.Name,
-		DatabaseID: table.DatabaseID,
-		Columns:    cols,
-		CreatedAt:  table.CreatedAt,
+		ID:          table.ID,
+		Name:        table.Name,
+		DatabaseID:  table.DatabaseID,
+		Columns:     cols,
+		RowPolicies: table.RowPolicies,
+		CreatedAt:   table.CreatedAt,
 	}
 }
 
 func NewColumnForResponse(col *entities.TableColumn) ColumnForResponse {
 	return ColumnForResponse{
-		Name: col.Name,
-		Type: col.Type,
-		ID:   col.ID,
-		Enum: col.Enum,
+		Name:       col.Name,
+		Type:       col.Type,
+		ID:         col.ID,
+		Enum:       col.Enum,
+		Policies:   col.Policies,
+		Link:       col.Link,
+		Lookup:     col.Lookup,
+		Rollup:     col.Rollup,
+		Formula:    col.Formula,
+		Autonumber: col.Autonumber,
+		ReadOnly:   col.ReadOnly,
 	}
 }
 
@@ -64,17 +87,57 @@ func NewTablesResponse(tables []*entities.Table) TablesResponse {
 }
 
 type UserInfoResponse struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 func NewUserInfoResponse(user *entities.User) *UserInfoResponse {
 	return &UserInfoResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+type InvitationResponse struct {
+	ID           int64                     `json:"id"`
+	DatabaseID   int64                     `json:"database_id"`
+	DatabaseName string                    `json:"database_name,omitempty"`
+	Email        string                    `json:"email"`
+	Role         entities.Role             `json:"role"`
+	Status       entities.InvitationStatus `json:"status"`
+	InvitedBy    int64                     `json:"invited_by"`
+	InviterName  string                    `json:"inviter_name,omitempty"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	ExpiresAt    time.Time                 `json:"expires_at"`
+}
+
+func NewInvitationResponse(invitation *entities.DatabaseInvitation) *InvitationResponse {
+	return &InvitationResponse{
+		ID:           invitation.ID,
+		DatabaseID:   invitation.DatabaseID,
+		DatabaseName: invitation.DatabaseName,
+		Email:        invitation.Email,
+		Role:         invitation.Role,
+		Status:       invitation.Status,
+		InvitedBy:    invitation.InvitedBy,
+		InviterName:  invitation.InviterName,
+		CreatedAt:    invitation.CreatedAt,
+		ExpiresAt:    invitation.ExpiresAt,
 	}
 }
+
+type InvitationsListResponse []*InvitationResponse
+
+func NewInvitationsListResponse(invitations []*entities.DatabaseInvitation) InvitationsListResponse {
+	res := make(InvitationsListResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		res = append(res, NewInvitationResponse(invitation))
+	}
+	return res
+}
@@ -6,7 +6,10 @@ import (
 	"backend/src/services"
 	"context"
 	"log"
+	"net/http"
 	"strconv"
+
+	"github.com/gin-gonic/gin"
 )
 
 func Ints64ToStrings(arr []int64) []string {
@@ -36,6 +39,7 @@ func SendActionToDBUsers(
 func ThrowUserFromDBTables(
 	ctx context.Context,
 	tablesService services.ITablesService,
+	tablesHub *web_sockets.Hub,
 	usersHub *web_sockets.Hub,
 	userID int64,
 	dbID int64,
@@ -46,8 +50,77 @@ func ThrowUserFromDBTables(
 		return err
 	}
 
+	ThrowUserFromTables(tablesHub, usersHub, userID, tableIDs)
+	return nil
+}
+
+func ThrowUserFromTables(
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	userID int64,
+	tableIDs []string,
+) {
 	for _, id := range tableIDs {
 		usersHub.Broadcast(strconv.FormatInt(userID, 10), entities.EventActionGoAwayFromTable, &entities.GoAwayFromTableMessage{TableID: id})
 	}
-	return nil
+	tablesHub.Disconnect(tableIDs, userID)
+}
+
+// LinkedTableView shows the linked tables to the user the way the user reads them directly.
+func LinkedTableView(databasesService services.IDatabasesService, userID int64) entities.LinkedTableView {
+	return func(ctx context.Context, table *entities.Table) (*entities.Table, error) {
+		role, permissions, err := databasesService.GetUsersTablePermissions(ctx, userID, table)
+		if err != nil {
+			return nil, err
+		}
+		if !permissions.Has(entities.PermissionRead) {
+			return nil, nil
+		}
+		return table.ForViewer(entities.TableViewer{UserID: userID, Role: role}), nil
+	}
+}
+
+// BroadcastToLinkingTables makes the subscribers of the tables linking the table fetch them again,
+// as the values they show of its rows have changed.
+func BroadcastToLinkingTables(
+	ctx context.Context,
+	tablesService services.ITablesService,
+	tablesHub *web_sockets.Hub,
+	table *entities.Table,
+) {
+	tableIDs, err := tablesService.ListLinkingTableIDs(ctx, table)
+	if err != nil {
+		log.Printf("Error BroadcastToLinkingTables: %v", err)
+		return
+	}
+
+	for _, id := range tableIDs {
+		tablesHub.Broadcast(id, entities.EventActionFetchTable, nil)
+	}
+}
+
+func NewSessionClient(c *gin.Context) entities.SessionClient {
+	return entities.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
+// SessionID returns the session the request was authenticated with.
+// Requests authenticated by a personal access token have no session.
+func SessionID(c *gin.Context) (string, bool) {
+	sessionID, ok := c.Get("session_id")
+	if !ok {
+		return "", false
+	}
+	return sessionID.(string), true
+}
+
+func RequireSession(c *gin.Context) (string, bool) {
+	sessionID, ok := SessionID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "personal access tokens are not allowed here"})
+		return "", false
+	}
+	return sessionID, true
 }
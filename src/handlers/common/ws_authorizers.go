@@ -0,0 +1,33 @@
+package common
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"context"
+	"strconv"
+)
+
+func NewTablesWSAuthorizer(
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+) web_sockets.Authorizer {
+	return func(ctx context.Context, userID int64, topic string) (bool, error) {
+		table, err := tablesService.GetTableByID(ctx, topic, false)
+		if err != nil {
+			if tables.IsErrTableNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		return databasesService.CheckUserTablePermission(ctx, userID, table, entities.PermissionRead)
+	}
+}
+
+func NewUsersWSAuthorizer() web_sockets.Authorizer {
+	return func(ctx context.Context, userID int64, topic string) (bool, error) {
+		return topic == strconv.FormatInt(userID, 10), nil
+	}
+}
@@ -3,6 +3,7 @@ package databases
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers"
+	"backend/src/handlers/common"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
 	"net/http"
@@ -12,21 +13,28 @@ import (
 )
 
 type createDatabaseHandler struct {
-	usersHub         *web_sockets.Hub
-	databasesService services.IDatabasesService
+	usersHub             *web_sockets.Hub
+	databasesService     services.IDatabasesService
+	organizationsService services.IOrganizationsService
 }
 
 func newCreateDatabaseHandler(
 	usersHub *web_sockets.Hub,
 	databasesService services.IDatabasesService,
+	organizationsService services.IOrganizationsService,
 ) handlers.IHandler {
 	return &createDatabaseHandler{
-		usersHub:         usersHub,
-		databasesService: databasesService,
+		usersHub:             usersHub,
+		databasesService:     databasesService,
+		organizationsService: organizationsService,
 	}
 }
 
 func (h *createDatabaseHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
 	req := createDatabaseRequestDto{}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
@@ -34,13 +42,29 @@ func (h *createDatabaseHandler) Handle(c *gin.Context) {
 	}
 
 	userID := c.MustGet("user_id").(int64)
-	database, err := h.databasesService.AddDatabase(c, userID, req.Name)
+	if req.OrganizationID != nil {
+		authorized, err := h.organizationsService.CheckUserRole(c, userID, *req.OrganizationID, entities.OrganizationRoleMember)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !authorized {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user is not a member of the organization"})
+			return
+		}
+	}
+
+	database, err := h.databasesService.AddDatabase(c, userID, req.Name, req.OrganizationID)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.usersHub.Broadcast(strconv.FormatInt(userID, 10), entities.EventActionFetchDatabases, nil)
+	if database.OrganizationID != nil {
+		_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, database.ID, entities.EventActionFetchDatabases)
+	} else {
+		h.usersHub.Broadcast(strconv.FormatInt(userID, 10), entities.EventActionFetchDatabases, nil)
+	}
 
 	c.JSON(http.StatusOK, newDatabaseResponse(database))
 }
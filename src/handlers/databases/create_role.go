@@ -0,0 +1,83 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createRoleHandler struct {
+	databasesService services.IDatabasesService
+}
+
+func newCreateRoleHandler(
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &createRoleHandler{
+		databasesService: databasesService,
+	}
+}
+
+func (h *createRoleHandler) Handle(c *gin.Context) {
+	req := roleRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	// defining roles is reserved to admins, as a role can carry any permission
+	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	role := &entities.DatabaseRole{
+		DatabaseID: dbIDInt,
+		Name:       entities.Role(req.Name),
+	}
+	role.Permissions.Set(req.permissions())
+
+	role, err = h.databasesService.CreateRole(c, role)
+	if err != nil {
+		if databases.IsErrRoleExists(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if databases.IsErrDatabaseNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "database not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newDatabaseRoleResponse(role))
+}
+
+func (h *createRoleHandler) Path() string {
+	return "/databases/:id/roles/create"
+}
+
+func (h *createRoleHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *createRoleHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,89 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteDatabaseHandler struct {
+	databasesService services.IDatabasesService
+	tablesService    services.ITablesService
+	tablesHub        *web_sockets.Hub
+	usersHub         *web_sockets.Hub
+}
+
+func newDeleteDatabaseHandler(
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+) handlers.IHandler {
+	return &deleteDatabaseHandler{
+		databasesService: databasesService,
+		tablesService:    tablesService,
+		tablesHub:        tablesHub,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *deleteDatabaseHandler) Handle(c *gin.Context) {
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	// Collected beforehand, the deleted tables are not listed anymore.
+	tableIDs, err := h.tablesService.ListIDsByDatabaseID(c, dbIDInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	userIDs, err := h.databasesService.GetDatabasesUsersIDs(c, dbIDInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.databasesService.DeleteDatabase(c, dbIDInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, userID := range userIDs {
+		common.ThrowUserFromTables(h.tablesHub, h.usersHub, userID, tableIDs)
+	}
+	h.usersHub.BroadcastMany(common.Ints64ToStrings(userIDs), entities.EventActionFetchDatabases, nil)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *deleteDatabaseHandler) Path() string {
+	return "/databases/:id/delete"
+}
+
+func (h *deleteDatabaseHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *deleteDatabaseHandler) AuthRequired() bool {
+	return true
+}
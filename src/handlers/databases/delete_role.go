@@ -0,0 +1,80 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteRoleHandler struct {
+	databasesService services.IDatabasesService
+}
+
+func newDeleteRoleHandler(
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &deleteRoleHandler{
+		databasesService: databasesService,
+	}
+}
+
+func (h *deleteRoleHandler) Handle(c *gin.Context) {
+	req := deleteRoleRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	err = h.databasesService.DeleteRole(c, dbIDInt, entities.Role(req.Name))
+	if err != nil {
+		if databases.IsErrBuiltinRole(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if databases.IsErrRoleInUse(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if databases.IsErrRoleNotFound(err) || databases.IsErrDatabaseNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "role not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *deleteRoleHandler) Path() string {
+	return "/databases/:id/roles/delete"
+}
+
+func (h *deleteRoleHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *deleteRoleHandler) AuthRequired() bool {
+	return true
+}
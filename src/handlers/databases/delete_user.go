@@ -6,6 +6,7 @@ import (
 	"backend/src/handlers/common"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
+	"backend/src/services/databases"
 	"net/http"
 	"strconv"
 
@@ -15,10 +16,12 @@ import (
 type deleteUserHandler struct {
 	databasesService services.IDatabasesService
 	tablesService    services.ITablesService
+	tablesHub        *web_sockets.Hub
 	usersHub         *web_sockets.Hub
 }
 
 func newDeleteUserHandler(
+	tablesHub *web_sockets.Hub,
 	usersHub *web_sockets.Hub,
 	databasesService services.IDatabasesService,
 	tablesService services.ITablesService,
@@ -26,6 +29,7 @@ func newDeleteUserHandler(
 	return &deleteUserHandler{
 		databasesService: databasesService,
 		tablesService:    tablesService,
+		tablesHub:        tablesHub,
 		usersHub:         usersHub,
 	}
 }
@@ -44,23 +48,28 @@ func (h *deleteUserHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleAdmin)
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CanManageMember(c, userID, dbIDInt, req.UserID)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user cannot manage this member"})
 		return
 	}
 
-	err = h.databasesService.DeleteUsersDatabaseRelation(c, req.UserID, dbIDInt)
+	err = h.databasesService.DeleteUsersDatabaseRelation(c, userID, req.UserID, dbIDInt)
 	if err != nil {
+		if databases.IsErrLastAdmin(err) || databases.IsErrOwnerMustStayAdmin(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	_ = common.ThrowUserFromDBTables(c, h.tablesService, h.usersHub, req.UserID, dbIDInt)
+	_ = common.ThrowUserFromDBTables(c, h.tablesService, h.tablesHub, h.usersHub, req.UserID, dbIDInt)
 	h.usersHub.Broadcast(strconv.FormatInt(req.UserID, 10), entities.EventActionFetchDatabases, nil)
 
 	c.Status(http.StatusOK)
@@ -10,15 +10,30 @@ func NewHandlers(
 	tablesService services.ITablesService,
 	databasesService services.IDatabasesService,
 	usersService services.IUsersService,
+	invitationsService services.IInvitationsService,
+	organizationsService services.IOrganizationsService,
+	tablesHub *web_sockets.Hub,
 	usersHub *web_sockets.Hub,
 ) []handlers.IHandler {
 	return []handlers.IHandler{
-		newCreateDatabaseHandler(usersHub, databasesService),
+		newCreateDatabaseHandler(usersHub, databasesService, organizationsService),
 		newListDatabasesHandler(databasesService, tablesService),
 		newGetDatabaseTablesHandler(tablesService, databasesService),
 		newUsersHandler(databasesService),
 		newSetRoleHandler(usersHub, databasesService, usersService),
-		newDeleteUserHandler(usersHub, databasesService, tablesService),
+		newDeleteUserHandler(tablesHub, usersHub, databasesService, tablesService),
 		newRoleHandler(databasesService),
+		newInviteHandler(usersHub, databasesService, invitationsService),
+		newInvitationsHandler(databasesService, invitationsService),
+		newRevokeInvitationHandler(usersHub, databasesService, invitationsService),
+		newRenameDatabaseHandler(usersHub, databasesService),
+		newDeleteDatabaseHandler(tablesHub, usersHub, databasesService, tablesService),
+		newRestoreDatabaseHandler(usersHub, databasesService),
+		newTrashHandler(databasesService),
+		newTransferOwnershipHandler(usersHub, databasesService),
+		newRolesHandler(databasesService),
+		newCreateRoleHandler(databasesService),
+		newUpdateRoleHandler(tablesHub, usersHub, databasesService, tablesService),
+		newDeleteRoleHandler(databasesService),
 	}
 }
@@ -0,0 +1,66 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type invitationsHandler struct {
+	databasesService   services.IDatabasesService
+	invitationsService services.IInvitationsService
+}
+
+func newInvitationsHandler(
+	databasesService services.IDatabasesService,
+	invitationsService services.IInvitationsService,
+) handlers.IHandler {
+	return &invitationsHandler{
+		databasesService:   databasesService,
+		invitationsService: invitationsService,
+	}
+}
+
+func (h *invitationsHandler) Handle(c *gin.Context) {
+	dbID := c.Param("id")
+	dbIDInt, err := strconv.ParseInt(dbID, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserPermission(c, c.MustGet("user_id").(int64), dbIDInt, entities.PermissionManageMembers)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have manage_members permission"})
+		return
+	}
+
+	invitationsList, err := h.invitationsService.ListDatabaseInvitations(c, dbIDInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewInvitationsListResponse(invitationsList))
+}
+
+func (h *invitationsHandler) Path() string {
+	return "/databases/:id/invitations"
+}
+
+func (h *invitationsHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *invitationsHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,99 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/rate_limiter"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"backend/src/services/invitations"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type inviteHandler struct {
+	databasesService   services.IDatabasesService
+	invitationsService services.IInvitationsService
+	usersHub           *web_sockets.Hub
+}
+
+func newInviteHandler(
+	usersHub *web_sockets.Hub,
+	databasesService services.IDatabasesService,
+	invitationsService services.IInvitationsService,
+) handlers.IHandler {
+	return &inviteHandler{
+		databasesService:   databasesService,
+		invitationsService: invitationsService,
+		usersHub:           usersHub,
+	}
+}
+
+func (h *inviteHandler) Handle(c *gin.Context) {
+	req := inviteRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	dbID := c.Param("id")
+	dbIDInt, err := strconv.ParseInt(dbID, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CanGrantRole(c, userID, dbIDInt, entities.Role(req.Role))
+	if err != nil {
+		if databases.IsErrRoleNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "role not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user cannot grant this role"})
+		return
+	}
+
+	invitation, err := h.invitationsService.Invite(c, userID, dbIDInt, req.Email, entities.Role(req.Role))
+	if err != nil {
+		if invitations.IsErrAlreadyMember(err) {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if invitation.UserID != nil {
+		h.usersHub.Broadcast(strconv.FormatInt(*invitation.UserID, 10), entities.EventActionFetchInvitations, nil)
+	}
+
+	c.JSON(http.StatusOK, common.NewInvitationResponse(invitation))
+}
+
+func (h *inviteHandler) Path() string {
+	return "/databases/:id/invite"
+}
+
+func (h *inviteHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *inviteHandler) AuthRequired() bool {
+	return true
+}
+
+func (h *inviteHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "invite", KeyBy: rate_limiter.KeyByUser, Limit: rate_limiter.Limit{Requests: 100, Window: time.Hour}},
+	}
+}
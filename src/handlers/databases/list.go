@@ -41,6 +41,12 @@ func (h *listDatabasesHandler) Handle(c *gin.Context) {
 		return
 	}
 
+	tables, err = h.databasesService.FilterReadableTables(c, c.MustGet("user_id").(int64), tables)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, newDatabaseListResponse(usersDatabases, tables))
 }
 
@@ -0,0 +1,75 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type renameDatabaseHandler struct {
+	databasesService services.IDatabasesService
+	usersHub         *web_sockets.Hub
+}
+
+func newRenameDatabaseHandler(
+	usersHub *web_sockets.Hub,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &renameDatabaseHandler{
+		databasesService: databasesService,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *renameDatabaseHandler) Handle(c *gin.Context) {
+	req := renameDatabaseRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CheckUserRole(c, userID, dbIDInt, entities.RoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	database, err := h.databasesService.RenameDatabase(c, userID, dbIDInt, req.Name)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, dbIDInt, entities.EventActionFetchDatabases)
+
+	c.JSON(http.StatusOK, newDatabaseResponse(database))
+}
+
+func (h *renameDatabaseHandler) Path() string {
+	return "/databases/:id/rename"
+}
+
+func (h *renameDatabaseHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *renameDatabaseHandler) AuthRequired() bool {
+	return true
+}
@@ -1,14 +1,49 @@
 package databases
 
+import "backend/src/domains/entities"
+
 type createDatabaseRequestDto struct {
-	Name string `json:"name" binding:"required"`
+	Name           string `json:"name" binding:"required"`
+	OrganizationID *int64 `json:"organization_id"`
 }
 
 type setRoleRequestDto struct {
 	UserID int64  `json:"user_id" binding:"required"`
-	Role   string `json:"role" binding:"required,oneof=reader writer admin"`
+	Role   string `json:"role" binding:"required,max=64,ne=none"`
 }
 
 type deleteUserRequestDto struct {
 	UserID int64 `json:"user_id" binding:"required"`
 }
+
+type inviteRequestDto struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,max=64,ne=none"`
+}
+
+type renameDatabaseRequestDto struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type transferOwnershipRequestDto struct {
+	UserID int64 `json:"user_id" binding:"required"`
+}
+
+type roleRequestDto struct {
+	Name        string   `json:"name" binding:"required,max=64"`
+	Permissions []string `json:"permissions" binding:"dive,oneof=read comment write_cells add_rows delete_rows edit_schema manage_members export"`
+}
+
+func (r roleRequestDto) permissions() entities.Permissions {
+	permissions := make(entities.Permissions, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		if !permissions.Has(entities.Permission(permission)) {
+			permissions = append(permissions, entities.Permission(permission))
+		}
+	}
+	return permissions
+}
+
+type deleteRoleRequestDto struct {
+	Name string `json:"name" binding:"required"`
+}
@@ -8,18 +8,22 @@ import (
 )
 
 type databaseResponse struct {
-	ID        int64                 `json:"id"`
-	Name      string                `json:"name"`
-	Role      entities.Role         `json:"role,omitempty"`
-	CreatedAt time.Time             `json:"created_at"`
-	Tables    common.TablesResponse `json:"tables"`
+	ID             int64                 `json:"id"`
+	Name           string                `json:"name"`
+	Role           entities.Role         `json:"role,omitempty"`
+	IsOwner        bool                  `json:"is_owner"`
+	OrganizationID *int64                `json:"organization_id,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeletedAt      *time.Time            `json:"deleted_at,omitempty"`
+	Tables         common.TablesResponse `json:"tables"`
 }
 
 func newDatabaseResponse(database *entities.Database) *databaseResponse {
 	return &databaseResponse{
-		ID:        database.ID,
-		Name:      database.Name,
-		CreatedAt: database.CreatedAt,
+		ID:             database.ID,
+		Name:           database.Name,
+		OrganizationID: database.OrganizationID,
+		CreatedAt:      database.CreatedAt,
 	}
 }
 
@@ -33,11 +37,13 @@ func newDatabaseListResponse(databases []*entities.UsersDatabase, tables []*enti
 	res := make(databaseListResponse, 0, len(databases))
 	for _, database := range databases {
 		res = append(res, &databaseResponse{
-			ID:        database.DatabaseID,
-			Name:      database.Name,
-			Role:      database.Role,
-			CreatedAt: database.CreatedAt,
-			Tables:    common.NewTablesResponse(tablesByDb[database.DatabaseID]),
+			ID:             database.DatabaseID,
+			Name:           database.Name,
+			Role:           database.Role,
+			IsOwner:        database.IsOwner(),
+			OrganizationID: database.OrganizationID,
+			CreatedAt:      database.CreatedAt,
+			Tables:         common.NewTablesResponse(tablesByDb[database.DatabaseID]),
 		})
 	}
 
@@ -50,13 +56,15 @@ func newDatabaseListResponse(databases []*entities.UsersDatabase, tables []*enti
 
 type databasesUserResponse struct {
 	*common.UserInfoResponse
-	Role entities.Role `json:"role"`
+	Role    entities.Role `json:"role"`
+	IsOwner bool          `json:"is_owner"`
 }
 
 func newDatabasesUserResponse(user *entities.DatabasesUser) *databasesUserResponse {
 	return &databasesUserResponse{
 		UserInfoResponse: common.NewUserInfoResponse(user.User),
 		Role:             user.Role,
+		IsOwner:          user.IsOwner,
 	}
 }
 
@@ -69,3 +77,51 @@ func newDatabaseUsersListResponse(users []*entities.DatabasesUser) databaseUsers
 	}
 	return res
 }
+
+func newDeletedDatabaseListResponse(databases []*entities.UsersDatabase) databaseListResponse {
+	res := make(databaseListResponse, 0, len(databases))
+	for _, database := range databases {
+		res = append(res, &databaseResponse{
+			ID:             database.DatabaseID,
+			Name:           database.Name,
+			Role:           database.Role,
+			IsOwner:        database.IsOwner(),
+			OrganizationID: database.OrganizationID,
+			CreatedAt:      database.CreatedAt,
+			DeletedAt:      database.DatabaseDeletedAt,
+		})
+	}
+	return res
+}
+
+type databaseRoleResponse struct {
+	Name        entities.Role        `json:"name"`
+	Permissions entities.Permissions `json:"permissions"`
+	Builtin     bool                 `json:"builtin"`
+}
+
+func newDatabaseRoleResponse(role *entities.DatabaseRole) *databaseRoleResponse {
+	return &databaseRoleResponse{
+		Name:        role.Name,
+		Permissions: role.GetPermissions(),
+	}
+}
+
+type databaseRolesListResponse []*databaseRoleResponse
+
+// newDatabaseRolesListResponse lists the built-in roles first, then the custom ones.
+func newDatabaseRolesListResponse(roles []*entities.DatabaseRole) databaseRolesListResponse {
+	res := make(databaseRolesListResponse, 0, len(entities.BuiltinRoles)+len(roles))
+	for _, role := range entities.BuiltinRoles {
+		permissions, _ := role.BuiltinPermissions()
+		res = append(res, &databaseRoleResponse{
+			Name:        role,
+			Permissions: permissions,
+			Builtin:     true,
+		})
+	}
+	for _, role := range roles {
+		res = append(res, newDatabaseRoleResponse(role))
+	}
+	return res
+}
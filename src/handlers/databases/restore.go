@@ -0,0 +1,68 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type restoreDatabaseHandler struct {
+	databasesService services.IDatabasesService
+	usersHub         *web_sockets.Hub
+}
+
+func newRestoreDatabaseHandler(
+	usersHub *web_sockets.Hub,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &restoreDatabaseHandler{
+		databasesService: databasesService,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *restoreDatabaseHandler) Handle(c *gin.Context) {
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserRoleWithDeleted(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	err = h.databasesService.RestoreDatabase(c, dbIDInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, dbIDInt, entities.EventActionFetchDatabases)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *restoreDatabaseHandler) Path() string {
+	return "/databases/:id/restore"
+}
+
+func (h *restoreDatabaseHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *restoreDatabaseHandler) AuthRequired() bool {
+	return true
+}
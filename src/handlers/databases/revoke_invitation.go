@@ -0,0 +1,82 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/invitations"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type revokeInvitationHandler struct {
+	databasesService   services.IDatabasesService
+	invitationsService services.IInvitationsService
+	usersHub           *web_sockets.Hub
+}
+
+func newRevokeInvitationHandler(
+	usersHub *web_sockets.Hub,
+	databasesService services.IDatabasesService,
+	invitationsService services.IInvitationsService,
+) handlers.IHandler {
+	return &revokeInvitationHandler{
+		databasesService:   databasesService,
+		invitationsService: invitationsService,
+		usersHub:           usersHub,
+	}
+}
+
+func (h *revokeInvitationHandler) Handle(c *gin.Context) {
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+	invitationID, err := strconv.ParseInt(c.Param("invitation_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserPermission(c, c.MustGet("user_id").(int64), dbIDInt, entities.PermissionManageMembers)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have manage_members permission"})
+		return
+	}
+
+	invitation, err := h.invitationsService.RevokeInvitation(c, dbIDInt, invitationID)
+	if err != nil {
+		if invitations.IsErrInvitationNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if invitation.UserID != nil {
+		h.usersHub.Broadcast(strconv.FormatInt(*invitation.UserID, 10), entities.EventActionFetchInvitations, nil)
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *revokeInvitationHandler) Path() string {
+	return "/databases/:id/invitations/:invitation_id/revoke"
+}
+
+func (h *revokeInvitationHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *revokeInvitationHandler) AuthRequired() bool {
+	return true
+}
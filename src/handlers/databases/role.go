@@ -29,7 +29,8 @@ func (h *roleHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	role, err := h.databasesService.GetUsersDatabaseRole(c, c.MustGet("user_id").(int64), dbIDInt)
+	userID := c.MustGet("user_id").(int64)
+	role, err := h.databasesService.GetUsersDatabaseRole(c, userID, dbIDInt)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -39,7 +40,13 @@ func (h *roleHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"role": role})
+	permissions, err := h.databasesService.GetUsersDatabasePermissions(c, userID, dbIDInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"role": role, "permissions": permissions})
 }
 
 func (h *roleHandler) Path() string {
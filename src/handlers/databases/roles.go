@@ -0,0 +1,61 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rolesHandler struct {
+	databasesService services.IDatabasesService
+}
+
+func newRolesHandler(
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &rolesHandler{
+		databasesService: databasesService,
+	}
+}
+
+func (h *rolesHandler) Handle(c *gin.Context) {
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserPermission(c, c.MustGet("user_id").(int64), dbIDInt, entities.PermissionRead)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have read permission"})
+		return
+	}
+
+	roles, err := h.databasesService.ListDatabaseRoles(c, dbIDInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newDatabaseRolesListResponse(roles))
+}
+
+func (h *rolesHandler) Path() string {
+	return "/databases/:id/roles"
+}
+
+func (h *rolesHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *rolesHandler) AuthRequired() bool {
+	return true
+}
@@ -5,6 +5,7 @@ import (
 	"backend/src/handlers"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
+	"backend/src/services/databases"
 	"backend/src/services/users"
 	"net/http"
 	"strconv"
@@ -44,13 +45,28 @@ func (h *setRoleHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleAdmin)
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CanManageMember(c, userID, dbIDInt, req.UserID)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user cannot manage this member"})
+		return
+	}
+
+	authorized, err = h.databasesService.CanGrantRole(c, userID, dbIDInt, entities.Role(req.Role))
+	if err != nil {
+		if databases.IsErrRoleNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "role not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user cannot grant this role"})
 		return
 	}
 
@@ -64,12 +80,16 @@ func (h *setRoleHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	_, err = h.databasesService.UpsertUsersDatabase(c, &entities.UsersDatabase{
+	_, err = h.databasesService.SetUsersRole(c, userID, &entities.UsersDatabase{
 		DatabaseID: dbIDInt,
 		UserID:     req.UserID,
 		Role:       entities.Role(req.Role),
 	})
 	if err != nil {
+		if databases.IsErrLastAdmin(err) || databases.IsErrOwnerMustStayAdmin(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -34,13 +34,13 @@ func (h *getDatabaseTablesHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleReader)
+	authorized, err := h.databasesService.CheckUserPermission(c, c.MustGet("user_id").(int64), dbIDInt, entities.PermissionRead)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have reader role"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have read permission"})
 		return
 	}
 
@@ -50,6 +50,12 @@ func (h *getDatabaseTablesHandler) Handle(c *gin.Context) {
 		return
 	}
 
+	tables, err = h.databasesService.FilterReadableTables(c, c.MustGet("user_id").(int64), tables)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, common.NewTablesResponse(tables))
 }
 
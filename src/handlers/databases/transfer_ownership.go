@@ -0,0 +1,91 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type transferOwnershipHandler struct {
+	usersHub         *web_sockets.Hub
+	databasesService services.IDatabasesService
+}
+
+func newTransferOwnershipHandler(
+	usersHub *web_sockets.Hub,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &transferOwnershipHandler{
+		usersHub:         usersHub,
+		databasesService: databasesService,
+	}
+}
+
+func (h *transferOwnershipHandler) Handle(c *gin.Context) {
+	req := transferOwnershipRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CheckUserRole(c, userID, dbIDInt, entities.RoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	database, err := h.databasesService.GetDatabaseByID(c, dbIDInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// databases created before owners were introduced may have none, any admin can claim those
+	if database.OwnerID != nil && *database.OwnerID != userID {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "only the owner can transfer the ownership"})
+		return
+	}
+
+	err = h.databasesService.TransferOwnership(c, userID, dbIDInt, req.UserID)
+	if err != nil {
+		if databases.IsErrNotMember(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, dbIDInt, entities.EventActionFetchDatabases)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *transferOwnershipHandler) Path() string {
+	return "/databases/:id/transfer-ownership"
+}
+
+func (h *transferOwnershipHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *transferOwnershipHandler) AuthRequired() bool {
+	return true
+}
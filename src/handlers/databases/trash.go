@@ -0,0 +1,43 @@
+package databases
+
+import (
+	"backend/src/handlers"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type trashHandler struct {
+	databasesService services.IDatabasesService
+}
+
+func newTrashHandler(
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &trashHandler{
+		databasesService: databasesService,
+	}
+}
+
+func (h *trashHandler) Handle(c *gin.Context) {
+	usersDatabases, err := h.databasesService.GetUsersDeletedDatabases(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newDeletedDatabaseListResponse(usersDatabases))
+}
+
+func (h *trashHandler) Path() string {
+	return "/databases/trash"
+}
+
+func (h *trashHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *trashHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,126 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type updateRoleHandler struct {
+	databasesService services.IDatabasesService
+	tablesService    services.ITablesService
+	tablesHub        *web_sockets.Hub
+	usersHub         *web_sockets.Hub
+}
+
+func newUpdateRoleHandler(
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+) handlers.IHandler {
+	return &updateRoleHandler{
+		databasesService: databasesService,
+		tablesService:    tablesService,
+		tablesHub:        tablesHub,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *updateRoleHandler) Handle(c *gin.Context) {
+	req := roleRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	dbIDInt, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	role, err := h.databasesService.UpdateRolePermissions(c, dbIDInt, entities.Role(req.Name), req.permissions())
+	if err != nil {
+		if databases.IsErrBuiltinRole(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if databases.IsErrRoleNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "role not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !role.GetPermissions().Has(entities.PermissionRead) {
+		h.throwRoleMembersFromTables(c, dbIDInt, role.Name)
+	}
+	_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, dbIDInt, entities.EventActionFetchDatabases)
+
+	c.JSON(http.StatusOK, newDatabaseRoleResponse(role))
+}
+
+// throwRoleMembersFromTables disconnects the members and table overrides with the role,
+// since they cannot read the tables anymore.
+func (h *updateRoleHandler) throwRoleMembersFromTables(c *gin.Context, databaseID int64, role entities.Role) {
+	databasesUsers, err := h.databasesService.GetDatabasesUsers(c, databaseID)
+	if err != nil {
+		log.Printf("Error listing users of database %d: %v", databaseID, err)
+		return
+	}
+	tableIDs, err := h.tablesService.ListIDsByDatabaseID(c, databaseID)
+	if err != nil {
+		log.Printf("Error listing tables of database %d: %v", databaseID, err)
+		return
+	}
+
+	for _, user := range databasesUsers {
+		if user.Role == role {
+			common.ThrowUserFromTables(h.tablesHub, h.usersHub, user.ID, tableIDs)
+		}
+	}
+	for _, tableID := range tableIDs {
+		tablesUsers, err := h.databasesService.GetTablesUsers(c, tableID)
+		if err != nil {
+			log.Printf("Error listing users of table %s: %v", tableID, err)
+			continue
+		}
+		for _, user := range tablesUsers {
+			if user.Role == role {
+				common.ThrowUserFromTables(h.tablesHub, h.usersHub, user.ID, []string{tableID})
+			}
+		}
+	}
+}
+
+func (h *updateRoleHandler) Path() string {
+	return "/databases/:id/roles/update"
+}
+
+func (h *updateRoleHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *updateRoleHandler) AuthRequired() bool {
+	return true
+}
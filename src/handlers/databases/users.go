@@ -30,13 +30,13 @@ func (h *usersHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleReader)
+	authorized, err := h.databasesService.CheckUserPermission(c, c.MustGet("user_id").(int64), dbIDInt, entities.PermissionRead)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have reader role"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have read permission"})
 		return
 	}
 
@@ -1,6 +1,10 @@
 package handlers
 
-import "github.com/gin-gonic/gin"
+import (
+	"backend/src/modules/rate_limiter"
+
+	"github.com/gin-gonic/gin"
+)
 
 type IHandler interface {
 	Handle(c *gin.Context)
@@ -8,3 +12,9 @@ type IHandler interface {
 	Method() string
 	AuthRequired() bool
 }
+
+// IRateLimitedHandler is implemented by handlers that need limits on top of the default ones.
+type IRateLimitedHandler interface {
+	IHandler
+	RateLimits() []rate_limiter.Rule
+}
@@ -0,0 +1,88 @@
+package invitations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/invitations"
+	"backend/src/services/users"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type acceptHandler struct {
+	invitationsService services.IInvitationsService
+	usersService       services.IUsersService
+	usersHub           *web_sockets.Hub
+}
+
+func newAcceptHandler(
+	invitationsService services.IInvitationsService,
+	usersService services.IUsersService,
+	usersHub *web_sockets.Hub,
+) handlers.IHandler {
+	return &acceptHandler{
+		invitationsService: invitationsService,
+		usersService:       usersService,
+		usersHub:           usersHub,
+	}
+}
+
+func (h *acceptHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation ID: " + err.Error()})
+		return
+	}
+
+	user, err := h.usersService.FindUserByID(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	invitation, err := h.invitationsService.AcceptInvitation(c, user, invitationID)
+	if err != nil {
+		if invitations.IsErrInvitationNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if invitations.IsErrEmailNotVerified(err) || invitations.IsErrInviterCannotGrantRole(err) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	userTopic := strconv.FormatInt(user.ID, 10)
+	h.usersHub.Broadcast(userTopic, entities.EventActionFetchInvitations, nil)
+	h.usersHub.Broadcast(userTopic, entities.EventActionFetchDatabases, nil)
+	h.usersHub.Broadcast(strconv.FormatInt(invitation.InvitedBy, 10), entities.EventActionFetchInvitations, nil)
+
+	c.JSON(http.StatusOK, common.NewInvitationResponse(invitation))
+}
+
+func (h *acceptHandler) Path() string {
+	return "/invitations/:id/accept"
+}
+
+func (h *acceptHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *acceptHandler) AuthRequired() bool {
+	return true
+}
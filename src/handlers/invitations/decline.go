@@ -0,0 +1,83 @@
+package invitations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/invitations"
+	"backend/src/services/users"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type declineHandler struct {
+	invitationsService services.IInvitationsService
+	usersService       services.IUsersService
+	usersHub           *web_sockets.Hub
+}
+
+func newDeclineHandler(
+	invitationsService services.IInvitationsService,
+	usersService services.IUsersService,
+	usersHub *web_sockets.Hub,
+) handlers.IHandler {
+	return &declineHandler{
+		invitationsService: invitationsService,
+		usersService:       usersService,
+		usersHub:           usersHub,
+	}
+}
+
+func (h *declineHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation ID: " + err.Error()})
+		return
+	}
+
+	user, err := h.usersService.FindUserByID(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	invitation, err := h.invitationsService.DeclineInvitation(c, user, invitationID)
+	if err != nil {
+		if invitations.IsErrInvitationNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	userTopic := strconv.FormatInt(user.ID, 10)
+	h.usersHub.Broadcast(userTopic, entities.EventActionFetchInvitations, nil)
+	h.usersHub.Broadcast(strconv.FormatInt(invitation.InvitedBy, 10), entities.EventActionFetchInvitations, nil)
+
+	c.JSON(http.StatusOK, common.NewInvitationResponse(invitation))
+}
+
+func (h *declineHandler) Path() string {
+	return "/invitations/:id/decline"
+}
+
+func (h *declineHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *declineHandler) AuthRequired() bool {
+	return true
+}
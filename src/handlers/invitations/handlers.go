@@ -0,0 +1,19 @@
+package invitations
+
+import (
+	"backend/src/handlers"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+)
+
+func NewHandlers(
+	invitationsService services.IInvitationsService,
+	usersService services.IUsersService,
+	usersHub *web_sockets.Hub,
+) []handlers.IHandler {
+	return []handlers.IHandler{
+		newListHandler(invitationsService),
+		newAcceptHandler(invitationsService, usersService, usersHub),
+		newDeclineHandler(invitationsService, usersService, usersHub),
+	}
+}
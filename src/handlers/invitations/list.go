@@ -1,7 +1,8 @@
-package users
+package invitations
 
 import (
 	"backend/src/handlers"
+	"backend/src/handlers/common"
 	"backend/src/services"
 	"net/http"
 
@@ -9,29 +10,29 @@ import (
 )
 
 type listHandler struct {
-	userService services.IUsersService
+	invitationsService services.IInvitationsService
 }
 
 func newListHandler(
-	userService services.IUsersService,
+	invitationsService services.IInvitationsService,
 ) handlers.IHandler {
 	return &listHandler{
-		userService: userService,
+		invitationsService: invitationsService,
 	}
 }
 
 func (h *listHandler) Handle(c *gin.Context) {
-	usersList, err := h.userService.ListUsers(c)
+	invitationsList, err := h.invitationsService.ListUserInvitations(c, c.MustGet("user_id").(int64))
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, newUsersListResponse(usersList))
+	c.JSON(http.StatusOK, common.NewInvitationsListResponse(invitationsList))
 }
 
 func (h *listHandler) Path() string {
-	return "/users/list"
+	return "/invitations/list"
 }
 
 func (h *listHandler) Method() string {
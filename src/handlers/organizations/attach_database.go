@@ -0,0 +1,100 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"backend/src/services/organizations"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type attachDatabaseHandler struct {
+	organizationsService services.IOrganizationsService
+	databasesService     services.IDatabasesService
+	usersHub             *web_sockets.Hub
+}
+
+func newAttachDatabaseHandler(
+	usersHub *web_sockets.Hub,
+	organizationsService services.IOrganizationsService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &attachDatabaseHandler{
+		organizationsService: organizationsService,
+		databasesService:     databasesService,
+		usersHub:             usersHub,
+	}
+}
+
+func (h *attachDatabaseHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := databaseRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID: " + err.Error()})
+		return
+	}
+
+	// attaching hands the database over to the organization admins, so both sides must agree
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.organizationsService.CheckUserRole(c, userID, orgID, entities.OrganizationRoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if authorized {
+		authorized, err = h.databasesService.CheckUserRole(c, userID, req.DatabaseID, entities.RoleAdmin)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role in both the organization and the database"})
+		return
+	}
+
+	err = h.organizationsService.AttachDatabase(c, orgID, req.DatabaseID)
+	if err != nil {
+		if organizations.IsErrDatabaseInOtherOrganization(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if databases.IsErrDatabaseNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "database not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, req.DatabaseID, entities.EventActionFetchDatabases)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *attachDatabaseHandler) Path() string {
+	return "/organizations/:id/databases/attach"
+}
+
+func (h *attachDatabaseHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *attachDatabaseHandler) AuthRequired() bool {
+	return true
+}
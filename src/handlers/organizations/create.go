@@ -0,0 +1,53 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createOrganizationHandler struct {
+	organizationsService services.IOrganizationsService
+}
+
+func newCreateOrganizationHandler(organizationsService services.IOrganizationsService) handlers.IHandler {
+	return &createOrganizationHandler{
+		organizationsService: organizationsService,
+	}
+}
+
+func (h *createOrganizationHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := createOrganizationRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	organization, err := h.organizationsService.CreateOrganization(c, c.MustGet("user_id").(int64), req.Name)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newOrganizationResponse(organization, entities.OrganizationRoleAdmin))
+}
+
+func (h *createOrganizationHandler) Path() string {
+	return "/organizations/create"
+}
+
+func (h *createOrganizationHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *createOrganizationHandler) AuthRequired() bool {
+	return true
+}
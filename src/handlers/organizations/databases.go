@@ -0,0 +1,64 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type databasesHandler struct {
+	organizationsService services.IOrganizationsService
+}
+
+func newDatabasesHandler(organizationsService services.IOrganizationsService) handlers.IHandler {
+	return &databasesHandler{
+		organizationsService: organizationsService,
+	}
+}
+
+func (h *databasesHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.organizationsService.CheckUserRole(c, c.MustGet("user_id").(int64), orgID, entities.OrganizationRoleMember)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user is not a member of the organization"})
+		return
+	}
+
+	databases, err := h.organizationsService.GetOrganizationDatabases(c, orgID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newOrganizationDatabasesListResponse(databases))
+}
+
+func (h *databasesHandler) Path() string {
+	return "/organizations/:id/databases"
+}
+
+func (h *databasesHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *databasesHandler) AuthRequired() bool {
+	return true
+}
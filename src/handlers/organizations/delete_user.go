@@ -0,0 +1,106 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/organizations"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteUserHandler struct {
+	organizationsService services.IOrganizationsService
+	databasesService     services.IDatabasesService
+	tablesService        services.ITablesService
+	tablesHub            *web_sockets.Hub
+	usersHub             *web_sockets.Hub
+}
+
+func newDeleteUserHandler(
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	organizationsService services.IOrganizationsService,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+) handlers.IHandler {
+	return &deleteUserHandler{
+		organizationsService: organizationsService,
+		databasesService:     databasesService,
+		tablesService:        tablesService,
+		tablesHub:            tablesHub,
+		usersHub:             usersHub,
+	}
+}
+
+func (h *deleteUserHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := deleteUserRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID: " + err.Error()})
+		return
+	}
+
+	// members may leave on their own, only admins remove others
+	userID := c.MustGet("user_id").(int64)
+	if userID != req.UserID {
+		authorized, err := h.organizationsService.CheckUserRole(c, userID, orgID, entities.OrganizationRoleAdmin)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !authorized {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+			return
+		}
+	}
+
+	err = h.organizationsService.DeleteUser(c, orgID, req.UserID)
+	if err != nil {
+		if organizations.IsErrLastAdmin(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if organizations.IsErrOrganizationNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	databases, err := h.organizationsService.GetOrganizationDatabases(c, orgID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	throwUsersWithoutAccess(c.Request.Context(), h.databasesService, h.tablesService, h.tablesHub, h.usersHub, []int64{req.UserID}, databases)
+	h.usersHub.Broadcast(strconv.FormatInt(req.UserID, 10), entities.EventActionFetchDatabases, nil)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *deleteUserHandler) Path() string {
+	return "/organizations/:id/delete-user"
+}
+
+func (h *deleteUserHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *deleteUserHandler) AuthRequired() bool {
+	return true
+}
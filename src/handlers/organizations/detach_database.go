@@ -0,0 +1,110 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"backend/src/services/organizations"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type detachDatabaseHandler struct {
+	organizationsService services.IOrganizationsService
+	databasesService     services.IDatabasesService
+	tablesService        services.ITablesService
+	tablesHub            *web_sockets.Hub
+	usersHub             *web_sockets.Hub
+}
+
+func newDetachDatabaseHandler(
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	organizationsService services.IOrganizationsService,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+) handlers.IHandler {
+	return &detachDatabaseHandler{
+		organizationsService: organizationsService,
+		databasesService:     databasesService,
+		tablesService:        tablesService,
+		tablesHub:            tablesHub,
+		usersHub:             usersHub,
+	}
+}
+
+func (h *detachDatabaseHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := databaseRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.organizationsService.CheckUserRole(c, c.MustGet("user_id").(int64), orgID, entities.OrganizationRoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	// the organization admins are only listed while the database belongs to the organization
+	userIDs, err := h.databasesService.GetDatabasesUsersIDs(c, req.DatabaseID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.organizationsService.DetachDatabase(c, orgID, req.DatabaseID)
+	if err != nil {
+		if organizations.IsErrDatabaseWithoutAdmins(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if organizations.IsErrDatabaseNotInOrganization(err) || databases.IsErrDatabaseNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "database not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	database, err := h.databasesService.GetDatabaseByID(c, req.DatabaseID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	throwUsersWithoutAccess(c.Request.Context(), h.databasesService, h.tablesService, h.tablesHub, h.usersHub, userIDs, []*entities.Database{database})
+	h.usersHub.BroadcastMany(common.Ints64ToStrings(userIDs), entities.EventActionFetchDatabases, nil)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *detachDatabaseHandler) Path() string {
+	return "/organizations/:id/databases/detach"
+}
+
+func (h *detachDatabaseHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *detachDatabaseHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,104 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"backend/src/services/organizations"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type grantDefaultRoleHandler struct {
+	organizationsService services.IOrganizationsService
+	databasesService     services.IDatabasesService
+	usersHub             *web_sockets.Hub
+}
+
+func newGrantDefaultRoleHandler(
+	usersHub *web_sockets.Hub,
+	organizationsService services.IOrganizationsService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &grantDefaultRoleHandler{
+		organizationsService: organizationsService,
+		databasesService:     databasesService,
+		usersHub:             usersHub,
+	}
+}
+
+// Handle gives every organization member who is not in the database yet the role in it.
+// Existing members keep their roles, so the grant never demotes anybody.
+func (h *grantDefaultRoleHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := grantDefaultRoleRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID: " + err.Error()})
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.organizationsService.CheckUserRole(c, userID, orgID, entities.OrganizationRoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	authorized, err = h.databasesService.CanGrantRole(c, userID, req.DatabaseID, entities.Role(req.Role))
+	if err != nil {
+		if databases.IsErrRoleNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "role not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user cannot grant this role"})
+		return
+	}
+
+	grantedUserIDs, err := h.organizationsService.GrantDefaultRole(c, userID, orgID, req.DatabaseID, entities.Role(req.Role))
+	if err != nil {
+		if organizations.IsErrDatabaseNotInOrganization(err) || databases.IsErrDatabaseNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "database not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.usersHub.BroadcastMany(common.Ints64ToStrings(grantedUserIDs), entities.EventActionFetchDatabases, nil)
+
+	c.JSON(http.StatusOK, &grantDefaultRoleResponse{UserIDs: grantedUserIDs})
+}
+
+func (h *grantDefaultRoleHandler) Path() string {
+	return "/organizations/:id/grant-default-role"
+}
+
+func (h *grantDefaultRoleHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *grantDefaultRoleHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,28 @@
+package organizations
+
+import (
+	"backend/src/handlers"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+)
+
+func NewHandlers(
+	organizationsService services.IOrganizationsService,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+	usersService services.IUsersService,
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+) []handlers.IHandler {
+	return []handlers.IHandler{
+		newCreateOrganizationHandler(organizationsService),
+		newListOrganizationsHandler(organizationsService),
+		newUsersHandler(organizationsService),
+		newSetRoleHandler(tablesHub, usersHub, organizationsService, databasesService, tablesService, usersService),
+		newDeleteUserHandler(tablesHub, usersHub, organizationsService, databasesService, tablesService),
+		newDatabasesHandler(organizationsService),
+		newAttachDatabaseHandler(usersHub, organizationsService, databasesService),
+		newDetachDatabaseHandler(tablesHub, usersHub, organizationsService, databasesService, tablesService),
+		newGrantDefaultRoleHandler(usersHub, organizationsService, databasesService),
+	}
+}
@@ -0,0 +1,46 @@
+package organizations
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type listOrganizationsHandler struct {
+	organizationsService services.IOrganizationsService
+}
+
+func newListOrganizationsHandler(organizationsService services.IOrganizationsService) handlers.IHandler {
+	return &listOrganizationsHandler{
+		organizationsService: organizationsService,
+	}
+}
+
+func (h *listOrganizationsHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	organizations, err := h.organizationsService.GetUsersOrganizations(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newOrganizationListResponse(organizations))
+}
+
+func (h *listOrganizationsHandler) Path() string {
+	return "/organizations/list"
+}
+
+func (h *listOrganizationsHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *listOrganizationsHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,23 @@
+package organizations
+
+type createOrganizationRequestDto struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type setRoleRequestDto struct {
+	UserID int64  `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required,oneof=member admin"`
+}
+
+type deleteUserRequestDto struct {
+	UserID int64 `json:"user_id" binding:"required"`
+}
+
+type databaseRequestDto struct {
+	DatabaseID int64 `json:"database_id" binding:"required"`
+}
+
+type grantDefaultRoleRequestDto struct {
+	DatabaseID int64  `json:"database_id" binding:"required"`
+	Role       string `json:"role" binding:"required,max=64,ne=none"`
+}
@@ -0,0 +1,80 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers/common"
+	"time"
+)
+
+type organizationResponse struct {
+	ID        int64                     `json:"id"`
+	Name      string                    `json:"name"`
+	Role      entities.OrganizationRole `json:"role,omitempty"`
+	CreatedAt time.Time                 `json:"created_at"`
+}
+
+func newOrganizationResponse(organization *entities.Organization, role entities.OrganizationRole) *organizationResponse {
+	return &organizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		Role:      role,
+		CreatedAt: organization.CreatedAt,
+	}
+}
+
+type organizationListResponse []*organizationResponse
+
+func newOrganizationListResponse(organizations []*entities.UsersOrganization) organizationListResponse {
+	res := make(organizationListResponse, 0, len(organizations))
+	for _, organization := range organizations {
+		res = append(res, &organizationResponse{
+			ID:        organization.OrganizationID,
+			Name:      organization.Name,
+			Role:      organization.Role,
+			CreatedAt: organization.CreatedAt,
+		})
+	}
+	return res
+}
+
+type organizationsUserResponse struct {
+	*common.UserInfoResponse
+	Role entities.OrganizationRole `json:"role"`
+}
+
+type organizationUsersListResponse []*organizationsUserResponse
+
+func newOrganizationUsersListResponse(users []*entities.OrganizationsUser) organizationUsersListResponse {
+	res := make(organizationUsersListResponse, 0, len(users))
+	for _, user := range users {
+		res = append(res, &organizationsUserResponse{
+			UserInfoResponse: common.NewUserInfoResponse(user.User),
+			Role:             user.Role,
+		})
+	}
+	return res
+}
+
+type organizationDatabaseResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type organizationDatabasesListResponse []*organizationDatabaseResponse
+
+func newOrganizationDatabasesListResponse(databases []*entities.Database) organizationDatabasesListResponse {
+	res := make(organizationDatabasesListResponse, 0, len(databases))
+	for _, database := range databases {
+		res = append(res, &organizationDatabaseResponse{
+			ID:        database.ID,
+			Name:      database.Name,
+			CreatedAt: database.CreatedAt,
+		})
+	}
+	return res
+}
+
+type grantDefaultRoleResponse struct {
+	UserIDs []int64 `json:"user_ids"`
+}
@@ -0,0 +1,119 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/organizations"
+	"backend/src/services/users"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type setRoleHandler struct {
+	organizationsService services.IOrganizationsService
+	databasesService     services.IDatabasesService
+	tablesService        services.ITablesService
+	usersService         services.IUsersService
+	tablesHub            *web_sockets.Hub
+	usersHub             *web_sockets.Hub
+}
+
+func newSetRoleHandler(
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	organizationsService services.IOrganizationsService,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+	usersService services.IUsersService,
+) handlers.IHandler {
+	return &setRoleHandler{
+		organizationsService: organizationsService,
+		databasesService:     databasesService,
+		tablesService:        tablesService,
+		usersService:         usersService,
+		tablesHub:            tablesHub,
+		usersHub:             usersHub,
+	}
+}
+
+func (h *setRoleHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := setRoleRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.organizationsService.CheckUserRole(c, c.MustGet("user_id").(int64), orgID, entities.OrganizationRoleAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		return
+	}
+
+	_, err = h.usersService.FindUserByID(c, req.UserID)
+	if err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.organizationsService.SetUsersRole(c, orgID, req.UserID, entities.OrganizationRole(req.Role))
+	if err != nil {
+		if organizations.IsErrLastAdmin(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if organizations.IsErrOrganizationNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// a demoted admin no longer manages the organization databases implicitly
+	if req.Role != string(entities.OrganizationRoleAdmin) {
+		databases, err := h.organizationsService.GetOrganizationDatabases(c, orgID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		throwUsersWithoutAccess(c.Request.Context(), h.databasesService, h.tablesService, h.tablesHub, h.usersHub, []int64{req.UserID}, databases)
+	}
+	h.usersHub.Broadcast(strconv.FormatInt(req.UserID, 10), entities.EventActionFetchDatabases, nil)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *setRoleHandler) Path() string {
+	return "/organizations/:id/set-role"
+}
+
+func (h *setRoleHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *setRoleHandler) AuthRequired() bool {
+	return true
+}
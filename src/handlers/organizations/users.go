@@ -0,0 +1,64 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type usersHandler struct {
+	organizationsService services.IOrganizationsService
+}
+
+func newUsersHandler(organizationsService services.IOrganizationsService) handlers.IHandler {
+	return &usersHandler{
+		organizationsService: organizationsService,
+	}
+}
+
+func (h *usersHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID: " + err.Error()})
+		return
+	}
+
+	authorized, err := h.organizationsService.CheckUserRole(c, c.MustGet("user_id").(int64), orgID, entities.OrganizationRoleMember)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user is not a member of the organization"})
+		return
+	}
+
+	organizationsUsers, err := h.organizationsService.GetOrganizationsUsers(c, orgID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newOrganizationUsersListResponse(organizationsUsers))
+}
+
+func (h *usersHandler) Path() string {
+	return "/organizations/:id/users"
+}
+
+func (h *usersHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *usersHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,47 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"context"
+	"log"
+)
+
+// throwUsersWithoutAccess sends the users away from the tables of the databases they cannot read anymore.
+// The context must not carry the caller's token scope, which would limit other users' access.
+func throwUsersWithoutAccess(
+	ctx context.Context,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	userIDs []int64,
+	databases []*entities.Database,
+) {
+	for _, database := range databases {
+		tables, err := tablesService.ListByDatabaseID(ctx, database.ID)
+		if err != nil {
+			log.Printf("Error listing tables of database %d: %v", database.ID, err)
+			continue
+		}
+
+		for _, userID := range userIDs {
+			tableIDs := make([]string, 0)
+			for _, table := range tables {
+				authorized, err := databasesService.CheckUserTablePermission(ctx, userID, table, entities.PermissionRead)
+				if err != nil {
+					log.Printf("Error checking access of user %d: %v", userID, err)
+					continue
+				}
+				if !authorized {
+					tableIDs = append(tableIDs, table.ID)
+				}
+			}
+			if len(tableIDs) > 0 {
+				common.ThrowUserFromTables(tablesHub, usersHub, userID, tableIDs)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package shares
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const filename = "export.xlsx"
+
+type exportHandler struct {
+	shareLinksService services.IShareLinksService
+	tablesService     services.ITablesService
+}
+
+func newExportHandler(
+	shareLinksService services.IShareLinksService,
+	tablesService services.ITablesService,
+) handlers.IHandler {
+	return &exportHandler{
+		shareLinksService: shareLinksService,
+		tablesService:     tablesService,
+	}
+}
+
+func (h *exportHandler) Handle(c *gin.Context) {
+	_, table, ok := openLink(c, h.shareLinksService, c.Param("token"), headerPassword(c))
+	if !ok {
+		return
+	}
+
+	file, err := h.tablesService.ExportTable(c, table, entities.SharedLinkedTableView)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"; filename*=UTF-8''`+filename)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	if _, err := file.WriteTo(c.Writer); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+}
+
+func (h *exportHandler) Path() string {
+	return "/shared/:token/export"
+}
+
+func (h *exportHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *exportHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *exportHandler) RateLimits() []rate_limiter.Rule {
+	return rateLimits
+}
@@ -0,0 +1,19 @@
+package shares
+
+import (
+	"backend/src/handlers"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+)
+
+func NewHandlers(
+	shareLinksService services.IShareLinksService,
+	tablesService services.ITablesService,
+	sharedTablesHub *web_sockets.Hub,
+) []handlers.IHandler {
+	return []handlers.IHandler{
+		newReadHandler(shareLinksService, tablesService),
+		newExportHandler(shareLinksService, tablesService),
+		newWSHandler(shareLinksService, sharedTablesHub),
+	}
+}
@@ -0,0 +1,80 @@
+package shares
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type readHandler struct {
+	shareLinksService services.IShareLinksService
+	tablesService     services.ITablesService
+}
+
+func newReadHandler(
+	shareLinksService services.IShareLinksService,
+	tablesService services.ITablesService,
+) handlers.IHandler {
+	return &readHandler{
+		shareLinksService: shareLinksService,
+		tablesService:     tablesService,
+	}
+}
+
+func (h *readHandler) Handle(c *gin.Context) {
+	var q entities.ReadTableParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	link, table, ok := openLink(c, h.shareLinksService, c.Param("token"), headerPassword(c))
+	if !ok {
+		return
+	}
+
+	if !table.ValidateParams(&q) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid filter"})
+		return
+	}
+
+	rows, err := h.tablesService.ReadTable(c, table, q)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.tablesService.ResolveReferences(c, table, rows, entities.SharedLinkedTableView)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	total, err := h.tablesService.GetTotalRows(c, table, q)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newSharedTableWithDataResponse(link, table, rows, total))
+}
+
+func (h *readHandler) Path() string {
+	return "/shared/:token"
+}
+
+func (h *readHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *readHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *readHandler) RateLimits() []rate_limiter.Rule {
+	return rateLimits
+}
@@ -0,0 +1,71 @@
+package shares
+
+import "backend/src/domains/entities"
+
+type sharedColumnResponse struct {
+	ID   string              `json:"id"`
+	Name string              `json:"name"`
+	Type entities.ColumnType `json:"type"`
+	Enum []string            `json:"enum"`
+}
+
+type sharedTableResponse struct {
+	ID        string                  `json:"id"`
+	Name      string                  `json:"name"`
+	Columns   []*sharedColumnResponse `json:"columns"`
+	TotalRows int64                   `json:"total_rows"`
+	Live      bool                    `json:"live"`
+}
+
+type sharedRowResponse struct {
+	ID   int64                  `json:"id"`
+	Data map[string]interface{} `json:"data"`
+}
+
+type sharedTableWithDataResponse struct {
+	Table *sharedTableResponse `json:"table"`
+	Rows  []*sharedRowResponse `json:"rows"`
+}
+
+func newSharedTableWithDataResponse(
+	link *entities.TableShareLink,
+	table *entities.Table,
+	rows []entities.TableRow,
+	total int64,
+) *sharedTableWithDataResponse {
+	res := &sharedTableWithDataResponse{
+		Table: &sharedTableResponse{
+			ID:        table.ID,
+			Name:      table.Name,
+			Columns:   make([]*sharedColumnResponse, 0, len(table.Columns)),
+			TotalRows: total,
+			Live:      link.Live,
+		},
+		Rows: make([]*sharedRowResponse, 0, len(rows)),
+	}
+
+	for _, col := range table.Columns {
+		if col.DeletedAt != nil {
+			continue
+		}
+		res.Table.Columns = append(res.Table.Columns, &sharedColumnResponse{
+			ID:   col.ID,
+			Name: col.Name,
+			Type: col.Type,
+			Enum: col.Enum,
+		})
+	}
+
+	for _, row := range rows {
+		data := make(map[string]interface{}, len(row))
+		for k, v := range row {
+			if k == "id" {
+				continue
+			}
+			data[k] = v
+		}
+		res.Rows = append(res.Rows, &sharedRowResponse{ID: row.GetID(), Data: data})
+	}
+
+	return res
+}
@@ -0,0 +1,74 @@
+package shares
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/rate_limiter"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/share_links"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const passwordHeader = "X-Share-Password"
+
+// Browsers cannot set headers on WebSocket handshakes, so the live feed receives the token and the password
+// as subprotocols instead of the query, which ends up in the access logs. The password is base64url encoded
+// to fit the protocol syntax, and the handshake is answered with wsProtocol.
+const (
+	wsProtocol               = "share-link"
+	wsTokenProtocolPrefix    = "share-token."
+	wsPasswordProtocolPrefix = "share-password."
+)
+
+// rateLimits keeps tokens and passwords from being guessed.
+var rateLimits = []rate_limiter.Rule{
+	{Name: "shares", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 60, Window: time.Minute}},
+}
+
+// headerPassword returns the link password sent in passwordHeader, if any.
+func headerPassword(c *gin.Context) *string {
+	if value := c.GetHeader(passwordHeader); value != "" {
+		return &value
+	}
+	return nil
+}
+
+// openLink resolves the link of the request, writing the error response when it cannot be opened.
+func openLink(
+	c *gin.Context,
+	shareLinksService services.IShareLinksService,
+	token string,
+	password *string,
+) (*entities.TableShareLink, *entities.Table, bool) {
+	link, table, err := shareLinksService.OpenLink(c, token, password)
+	if err != nil {
+		switch {
+		case share_links.IsErrShareLinkNotFound(err):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "share link not found"})
+		case share_links.IsErrPasswordRequired(err):
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "password required"})
+		case share_links.IsErrWrongPassword(err):
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "wrong password"})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return nil, nil, false
+	}
+	return link, table, true
+}
+
+// NewTablesRelay forwards the table events to the share link subscribers.
+// Cell values are not forwarded as is, since the link may not show the cell, the clients refetch instead.
+func NewTablesRelay(sharedTablesHub *web_sockets.Hub) web_sockets.Relay {
+	return func(topic, eventAction string) {
+		switch eventAction {
+		case entities.EventActionFetchTable, entities.EventActionSetCellValue:
+			sharedTablesHub.Broadcast(topic, entities.EventActionFetchTable, nil)
+		case entities.EventActionGoAwayFromTable:
+			sharedTablesHub.Broadcast(topic, entities.EventActionGoAwayFromTable, nil)
+		}
+	}
+}
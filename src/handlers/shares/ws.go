@@ -0,0 +1,92 @@
+package shares
+
+import (
+	"backend/src/handlers"
+	"backend/src/modules/rate_limiter"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"encoding/base64"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type wsHandler struct {
+	shareLinksService services.IShareLinksService
+	sharedTablesHub   *web_sockets.Hub
+}
+
+func newWSHandler(
+	shareLinksService services.IShareLinksService,
+	sharedTablesHub *web_sockets.Hub,
+) handlers.IHandler {
+	return &wsHandler{
+		shareLinksService: shareLinksService,
+		sharedTablesHub:   sharedTablesHub,
+	}
+}
+
+func (h *wsHandler) Handle(c *gin.Context) {
+	token, password, ok := wsCredentials(c)
+	if !ok {
+		return
+	}
+
+	link, table, ok := openLink(c, h.shareLinksService, token, password)
+	if !ok {
+		return
+	}
+	if !link.Live {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "share link is not live"})
+		return
+	}
+
+	web_sockets.Serve(h.sharedTablesHub, c, table.ID, link.WSClientID(), wsProtocol)
+}
+
+// wsCredentials reads the token and the password from the subprotocols of the handshake,
+// writing the error response when they are malformed.
+func wsCredentials(c *gin.Context) (string, *string, bool) {
+	protocols := websocket.Subprotocols(c.Request)
+	if !slices.Contains(protocols, wsProtocol) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "missing " + wsProtocol + " subprotocol"})
+		return "", nil, false
+	}
+
+	var token string
+	var password *string
+	for _, protocol := range protocols {
+		if value, ok := strings.CutPrefix(protocol, wsTokenProtocolPrefix); ok {
+			token = value
+		}
+		if value, ok := strings.CutPrefix(protocol, wsPasswordProtocolPrefix); ok {
+			decoded, err := base64.RawURLEncoding.DecodeString(value)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid password encoding"})
+				return "", nil, false
+			}
+			value = string(decoded)
+			password = &value
+		}
+	}
+	return token, password, true
+}
+
+func (h *wsHandler) Path() string {
+	return "/ws/shared"
+}
+
+func (h *wsHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *wsHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *wsHandler) RateLimits() []rate_limiter.Rule {
+	return rateLimits
+}
@@ -53,13 +53,13 @@ func (h *addColumnHandler) Handle(c *gin.Context) {
 	}
 
 	userID := c.MustGet("user_id").(int64)
-	authorized, err := h.databasesService.CheckUserRole(c, userID, table.DatabaseID, entities.RoleAdmin)
+	authorized, err := h.databasesService.CheckUserTablePermission(c, userID, table, entities.PermissionEditSchema)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have edit_schema permission"})
 		return
 	}
 
@@ -70,6 +70,10 @@ func (h *addColumnHandler) Handle(c *gin.Context) {
 	}
 	table, err = h.tablesService.AddColumnToTable(c, col, req.TableID)
 	if err != nil {
+		if tables.IsErrInvalidColumnReference(err) || tables.IsErrInvalidFormula(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -3,6 +3,7 @@ package tables
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers"
+	"backend/src/handlers/common"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
 	"backend/src/services/tables"
@@ -58,16 +59,18 @@ func (h *addRowHandler) Handle(c *gin.Context) {
 	}
 
 	userID := c.MustGet("user_id").(int64)
-	authorized, err := h.databasesService.CheckUserRole(c, userID, table.DatabaseID, entities.RoleWriter)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have writer role"})
+	if !permissions.Has(entities.PermissionAddRows) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have add_rows permission"})
 		return
 	}
 
+	table = table.ForViewer(entities.TableViewer{UserID: userID, Role: role})
+
 	existentColumns := make(map[string]*entities.TableColumn, len(table.Columns))
 	for _, col := range table.Columns {
 		if col.DeletedAt != nil {
@@ -83,9 +86,25 @@ func (h *addRowHandler) Handle(c *gin.Context) {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "column " + colID + " does not exist"})
 			return
 		}
+		if column.ReadOnly {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "column " + colID + " is read-only"})
+			return
+		}
 
 		if !column.ValidateColumnValue(value) {
 			invalidValues = append(invalidValues, value)
+			continue
+		}
+		value = column.NormalizeColumnValue(value)
+		req.Data[colID] = value
+
+		linked, err := h.tablesService.ValidateLinkedRows(c, table, column, value)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !linked {
+			invalidValues = append(invalidValues, value)
 		}
 	}
 
@@ -96,6 +115,10 @@ func (h *addRowHandler) Handle(c *gin.Context) {
 
 	row, err := h.tablesService.AddRow(c, userID, table, req.Data, req.SortIndex)
 	if err != nil {
+		if tables.IsErrRowOutsidePolicies(err) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "row would fall outside the row policies"})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -115,6 +138,12 @@ func (h *addRowHandler) Handle(c *gin.Context) {
 		return
 	}
 
+	err = h.tablesService.ResolveReferences(c, table, []entities.TableRow{row}, common.LinkedTableView(h.databasesService, userID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, newRowResponse(row))
 }
 
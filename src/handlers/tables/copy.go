@@ -0,0 +1,99 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type copyTableHandler struct {
+	tablesService    services.ITablesService
+	databasesService services.IDatabasesService
+	usersHub         *web_sockets.Hub
+}
+
+func newCopyTableHandler(
+	usersHub *web_sockets.Hub,
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &copyTableHandler{
+		tablesService:    tablesService,
+		databasesService: databasesService,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *copyTableHandler) Handle(c *gin.Context) {
+	req := copyTableRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+	if req.WithChangelog && !req.WithData {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "changelog can only be copied with the data"})
+		return
+	}
+
+	unlock := h.tablesService.ReadLockTable(req.TableID)
+	defer unlock()
+	table, err := h.tablesService.GetTableByID(c, req.TableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+	for _, databaseID := range []int64{table.DatabaseID, req.DatabaseID} {
+		authorized, err := h.databasesService.CheckUserRole(c, userID, databaseID, entities.RoleAdmin)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !authorized {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role in both databases"})
+			return
+		}
+	}
+
+	name := req.Name
+	if name == "" {
+		name = table.Name
+	}
+
+	copiedTable, err := h.tablesService.CopyTable(c, userID, req.TableID, req.DatabaseID, name, req.WithData, req.WithChangelog)
+	if err != nil {
+		if tables.IsErrTableHasLinks(err) {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, copiedTable.DatabaseID, entities.EventActionFetchDatabases)
+
+	c.JSON(http.StatusOK, common.NewTableResponse(copiedTable))
+}
+
+func (h *copyTableHandler) Path() string {
+	return "/tables/copy"
+}
+
+func (h *copyTableHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *copyTableHandler) AuthRequired() bool {
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"backend/src/handlers/common"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
+	"backend/src/services/tables"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -36,13 +37,14 @@ func (h *createTableHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), req.DatabaseID, entities.RoleAdmin)
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CheckUserPermission(c, userID, req.DatabaseID, entities.PermissionEditSchema)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have edit_schema permission"})
 		return
 	}
 
@@ -51,8 +53,12 @@ func (h *createTableHandler) Handle(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	table, err = h.tablesService.CreateTable(c, table)
+	table, err = h.tablesService.CreateTable(c, userID, table)
 	if err != nil {
+		if tables.IsErrInvalidColumnReference(err) || tables.IsErrInvalidFormula(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,99 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/modules/password_hasher"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createShareLinkHandler struct {
+	tablesService     services.ITablesService
+	databasesService  services.IDatabasesService
+	shareLinksService services.IShareLinksService
+}
+
+func newCreateShareLinkHandler(
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+	shareLinksService services.IShareLinksService,
+) handlers.IHandler {
+	return &createShareLinkHandler{
+		tablesService:     tablesService,
+		databasesService:  databasesService,
+		shareLinksService: shareLinksService,
+	}
+}
+
+func (h *createShareLinkHandler) Handle(c *gin.Context) {
+	req := createShareLinkRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+		return
+	}
+
+	table, err := h.tablesService.GetTableByID(c, req.TableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CheckUserTablePermission(c, userID, table, entities.PermissionManageMembers)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have manage_members permission"})
+		return
+	}
+
+	link, token, err := h.shareLinksService.CreateLink(c, req.toEntity(userID), req.Password)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		if tables.IsErrColumnNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "column not found"})
+			return
+		}
+		if password_hasher.IsErrPasswordTooLong(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, &createdShareLinkResponse{
+		shareLinkResponse: newShareLinkResponse(link),
+		Token:             token,
+	})
+}
+
+func (h *createShareLinkHandler) Path() string {
+	return "/tables/share-links/create"
+}
+
+func (h *createShareLinkHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *createShareLinkHandler) AuthRequired() bool {
+	return true
+}
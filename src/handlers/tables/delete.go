@@ -52,17 +52,18 @@ func (h *deleteTableHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), table.DatabaseID, entities.RoleAdmin)
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CheckUserTablePermission(c, userID, table, entities.PermissionEditSchema)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have edit_schema permission"})
 		return
 	}
 
-	err = h.tablesService.DeleteTable(c, req.TableID)
+	err = h.tablesService.DeleteTable(c, userID, req.TableID)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
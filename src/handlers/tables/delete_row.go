@@ -3,6 +3,7 @@ package tables
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers"
+	"backend/src/handlers/common"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
 	"backend/src/services/tables"
@@ -58,13 +59,23 @@ func (h *deleteRowHandler) Handle(c *gin.Context) {
 	}
 
 	userID := c.MustGet("user_id").(int64)
-	authorized, err := h.databasesService.CheckUserRole(c, userID, table.DatabaseID, entities.RoleWriter)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have writer role"})
+	if !permissions.Has(entities.PermissionDeleteRows) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have delete_rows permission"})
+		return
+	}
+
+	accessible, err := h.tablesService.IsRowAccessible(c, table.ForViewer(entities.TableViewer{UserID: userID, Role: role}), req.RowID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !accessible {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "row not found"})
 		return
 	}
 
@@ -80,6 +91,7 @@ func (h *deleteRowHandler) Handle(c *gin.Context) {
 	}
 
 	h.tablesHub.Broadcast(tableID, entities.EventActionFetchTable, nil)
+	common.BroadcastToLinkingTables(c, h.tablesService, h.tablesHub, table)
 
 	rowChange := &entities.RowChange{
 		ChangeType: entities.ChangeTypeDelete,
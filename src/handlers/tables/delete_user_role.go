@@ -0,0 +1,82 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteUserRoleHandler struct {
+	tablesService    services.ITablesService
+	databasesService services.IDatabasesService
+	usersHub         *web_sockets.Hub
+}
+
+func newDeleteUserRoleHandler(
+	usersHub *web_sockets.Hub,
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &deleteUserRoleHandler{
+		tablesService:    tablesService,
+		databasesService: databasesService,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *deleteUserRoleHandler) Handle(c *gin.Context) {
+	req := deleteUserRoleRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	table, err := h.tablesService.GetTableByID(c, req.TableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// table roles are managed at the database level, see setUserRoleHandler
+	authorized, err := h.databasesService.CanManageMember(c, c.MustGet("user_id").(int64), table.DatabaseID, req.UserID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user cannot manage this member"})
+		return
+	}
+
+	err = h.databasesService.DeleteUsersTableRole(c, req.UserID, table.ID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.usersHub.Broadcast(strconv.FormatInt(req.UserID, 10), entities.EventActionFetchDatabases, nil)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *deleteUserRoleHandler) Path() string {
+	return "/tables/delete-user-role"
+}
+
+func (h *deleteUserRoleHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *deleteUserRoleHandler) AuthRequired() bool {
+	return true
+}
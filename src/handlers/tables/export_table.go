@@ -3,6 +3,7 @@ package tables
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers"
+	"backend/src/handlers/common"
 	"backend/src/services"
 	"backend/src/services/tables"
 	"net/http"
@@ -44,17 +45,20 @@ func (h *exportTableHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), table.DatabaseID, entities.RoleReader)
+	userID := c.MustGet("user_id").(int64)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have reader role"})
+	if !permissions.Has(entities.PermissionExport) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have export permission"})
 		return
 	}
 
-	file, err := h.tablesService.ExportTable(c, table)
+	table = table.ForViewer(entities.TableViewer{UserID: userID, Role: role})
+
+	file, err := h.tablesService.ExportTable(c, table, common.LinkedTableView(h.databasesService, userID))
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
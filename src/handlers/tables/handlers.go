@@ -11,8 +11,10 @@ func NewHandlers(
 	databasesService services.IDatabasesService,
 	fileService services.IFileService,
 	changelogService services.IChangelogService,
+	shareLinksService services.IShareLinksService,
 	tablesHub *web_sockets.Hub,
 	usersHub *web_sockets.Hub,
+	sharedTablesHub *web_sockets.Hub,
 ) []handlers.IHandler {
 	return []handlers.IHandler{
 		newCreateTableHandler(usersHub, tablesService, databasesService),
@@ -31,5 +33,15 @@ func NewHandlers(
 		newInfoHandler(tablesService, databasesService),
 		newDeleteTableHandler(tablesHub, usersHub, tablesService, databasesService),
 		newRestoreTableHandler(usersHub, tablesService, databasesService),
+		newMoveTableHandler(tablesHub, usersHub, tablesService, databasesService),
+		newCopyTableHandler(usersHub, tablesService, databasesService),
+		newRoleHandler(tablesService, databasesService),
+		newUsersHandler(tablesService, databasesService),
+		newSetUserRoleHandler(tablesHub, usersHub, tablesService, databasesService),
+		newDeleteUserRoleHandler(usersHub, tablesService, databasesService),
+		newSetRowPoliciesHandler(tablesHub, tablesService, databasesService),
+		newCreateShareLinkHandler(tablesService, databasesService, shareLinksService),
+		newShareLinksHandler(tablesService, databasesService, shareLinksService),
+		newRevokeShareLinkHandler(sharedTablesHub, tablesService, databasesService, shareLinksService),
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"backend/src/handlers/common"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
+	"backend/src/services/tables"
 	"net/http"
 	"strconv"
 
@@ -48,13 +49,14 @@ func (h *importTableHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), dbIDInt, entities.RoleAdmin)
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CheckUserPermission(c, userID, dbIDInt, entities.PermissionEditSchema)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role"})
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have edit_schema permission"})
 		return
 	}
 
@@ -84,8 +86,19 @@ func (h *importTableHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	table, err := h.tablesService.ImportTable(c, tableName, dbIDInt, columns, data)
+	// the listed columns hold options separated as in the exported files, e.g. "backend, urgent"
+	multiSelectColumns := c.PostFormArray("multi_select_columns")
+
+	table, err := h.tablesService.ImportTable(c, userID, tableName, dbIDInt, columns, data, multiSelectColumns)
 	if err != nil {
+		if tables.IsErrColumnNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "multi-select column not found in the file"})
+			return
+		}
+		if tables.IsErrInvalidColumnValue(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
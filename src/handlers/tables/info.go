@@ -43,16 +43,19 @@ func (h *infoHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), table.DatabaseID, entities.RoleReader)
+	userID := c.MustGet("user_id").(int64)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have writer role"})
+	if !permissions.Has(entities.PermissionRead) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have read permission"})
 		return
 	}
 
+	table = table.ForViewer(entities.TableViewer{UserID: userID, Role: role})
+
 	c.JSON(http.StatusOK, common.NewTableResponse(table))
 }
 
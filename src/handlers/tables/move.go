@@ -0,0 +1,124 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type moveTableHandler struct {
+	tablesService    services.ITablesService
+	databasesService services.IDatabasesService
+	tablesHub        *web_sockets.Hub
+	usersHub         *web_sockets.Hub
+}
+
+func newMoveTableHandler(
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &moveTableHandler{
+		tablesService:    tablesService,
+		databasesService: databasesService,
+		tablesHub:        tablesHub,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *moveTableHandler) Handle(c *gin.Context) {
+	req := moveTableRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	unlock := h.tablesService.LockTable(req.TableID)
+	defer unlock()
+	table, err := h.tablesService.GetTableByID(c, req.TableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+	for _, databaseID := range []int64{table.DatabaseID, req.DatabaseID} {
+		authorized, err := h.databasesService.CheckUserRole(c, userID, databaseID, entities.RoleAdmin)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !authorized {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have admin role in both databases"})
+			return
+		}
+	}
+
+	sourceUserIDs, err := h.databasesService.GetDatabasesUsersIDs(c, table.DatabaseID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	movedTable, err := h.tablesService.MoveTable(c, req.TableID, req.DatabaseID)
+	if err != nil {
+		if tables.IsErrSameDatabase(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if tables.IsErrTableHasLinks(err) {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.throwUsersWithoutAccess(c, movedTable, sourceUserIDs)
+	h.tablesHub.Broadcast(req.TableID, entities.EventActionFetchTable, nil)
+	_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, table.DatabaseID, entities.EventActionFetchDatabases)
+	_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, movedTable.DatabaseID, entities.EventActionFetchDatabases)
+
+	c.JSON(http.StatusOK, common.NewTableResponse(movedTable))
+}
+
+// throwUsersWithoutAccess sends the users of the source database who cannot read the moved table away from it.
+func (h *moveTableHandler) throwUsersWithoutAccess(c *gin.Context, table *entities.Table, userIDs []int64) {
+	// the request context carries the caller's token scope, which must not limit other users' access
+	ctx := c.Request.Context()
+
+	for _, userID := range userIDs {
+		authorized, err := h.databasesService.CheckUserTablePermission(ctx, userID, table, entities.PermissionRead)
+		if err != nil {
+			log.Printf("Error checking access of user %d: %v", userID, err)
+			continue
+		}
+		if !authorized {
+			common.ThrowUserFromTables(h.tablesHub, h.usersHub, userID, []string{table.ID})
+		}
+	}
+}
+
+func (h *moveTableHandler) Path() string {
+	return "/tables/move"
+}
+
+func (h *moveTableHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *moveTableHandler) AuthRequired() bool {
+	return true
+}
@@ -54,13 +54,24 @@ func (h *moveRowHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), table.DatabaseID, entities.RoleWriter)
+	userID := c.MustGet("user_id").(int64)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have writer role"})
+	if !permissions.Has(entities.PermissionWriteCells) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have write_cells permission"})
+		return
+	}
+
+	accessible, err := h.tablesService.IsRowAccessible(c, table.ForViewer(entities.TableViewer{UserID: userID, Role: role}), req.RowID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !accessible {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "row not found"})
 		return
 	}
 
@@ -3,6 +3,7 @@ package tables
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers"
+	"backend/src/handlers/common"
 	"backend/src/services"
 	"backend/src/services/tables"
 	"net/http"
@@ -47,22 +48,32 @@ func (h *readTableHandler) Handle(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+
+	userID := c.MustGet("user_id").(int64)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !permissions.Has(entities.PermissionRead) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have read permission"})
+		return
+	}
+
+	table = table.ForViewer(entities.TableViewer{UserID: userID, Role: role})
+	// validated against the view, so hidden columns cannot be filtered or sorted by
 	if !table.ValidateParams(&q) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid filter"})
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), table.DatabaseID, entities.RoleReader)
+	rows, err := h.tablesService.ReadTable(c, table, q)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have writer role"})
-		return
-	}
 
-	rows, err := h.tablesService.ReadTable(c, table, q)
+	err = h.tablesService.ResolveReferences(c, table, rows, common.LinkedTableView(h.databasesService, userID))
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
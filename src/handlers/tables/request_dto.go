@@ -3,6 +3,8 @@ package tables
 import (
 	"backend/src/domains/entities"
 	"fmt"
+	"strings"
+	"time"
 )
 
 type createTableRequestDto struct {
@@ -28,19 +30,124 @@ func (c *createTableRequestDto) toEntity() (*entities.Table, error) {
 }
 
 type column struct {
-	Name string              `json:"name" binding:"required"`
-	Type entities.ColumnType `json:"type" binding:"required,oneof=text numeric enum timestamp"`
-	Enum []string            `json:"enum" binding:"omitempty,dive,required"`
+	Name     string              `json:"name" binding:"required"`
+	Type     entities.ColumnType `json:"type" binding:"required,oneof=text numeric enum multi_select timestamp boolean date url email phone link lookup rollup formula autonumber created_at created_by updated_at updated_by"`
+	Enum     []string            `json:"enum" binding:"omitempty,dive,required"`
+	Policies []columnPolicy      `json:"policies" binding:"omitempty,dive"`
+	Link     *columnLink         `json:"link" binding:"required_if=Type link,omitempty"`
+	Lookup   *columnLookup       `json:"lookup" binding:"required_if=Type lookup,omitempty"`
+	Rollup   *columnRollup       `json:"rollup" binding:"required_if=Type rollup,omitempty"`
+	// Formula references the columns by name, e.g. {Price} * {Qty}
+	Formula    string            `json:"formula" binding:"required_if=Type formula"`
+	Autonumber *columnAutonumber `json:"autonumber" binding:"omitempty"`
+}
+
+type columnLink struct {
+	TableID         string `json:"table_id" binding:"required"`
+	DisplayColumnID string `json:"display_column_id" binding:"required"`
+}
+
+type columnLookup struct {
+	LinkColumnID string `json:"link_column_id" binding:"required"`
+	ColumnID     string `json:"column_id" binding:"required"`
+}
+
+type columnRollup struct {
+	LinkColumnID string                  `json:"link_column_id" binding:"required"`
+	ColumnID     string                  `json:"column_id" binding:"required_unless=Function count"`
+	Function     entities.RollupFunction `json:"function" binding:"required,oneof=count sum min max"`
+}
+
+type columnAutonumber struct {
+	Prefix string `json:"prefix" binding:"max=32"`
+}
+
+type columnPolicy struct {
+	Role   entities.Role         `json:"role" binding:"required_without=UserID,excluded_with=UserID,omitempty,max=64,ne=admin,ne=none"`
+	UserID *int64                `json:"user_id" binding:"omitempty,min=1"`
+	Access entities.ColumnAccess `json:"access" binding:"required,oneof=hidden read_only full"`
+}
+
+func (c *column) policiesToEntity() []*entities.ColumnPolicy {
+	if len(c.Policies) == 0 {
+		return nil
+	}
+
+	policies := make([]*entities.ColumnPolicy, 0, len(c.Policies))
+	for _, policy := range c.Policies {
+		policies = append(policies, &entities.ColumnPolicy{
+			Role:   policy.Role,
+			UserID: policy.UserID,
+			Access: policy.Access,
+		})
+	}
+	return policies
+}
+
+// referencesToEntity sets the reference, formula or autonumber configuration matching the column type, others are dropped.
+func (c *column) referencesToEntity(col *entities.TableColumn) {
+	switch c.Type {
+	case entities.ColumnTypeLink:
+		col.Link = &entities.ColumnLink{
+			TableID:         c.Link.TableID,
+			DisplayColumnID: c.Link.DisplayColumnID,
+		}
+	case entities.ColumnTypeLookup:
+		col.Lookup = &entities.ColumnLookup{
+			LinkColumnID: c.Lookup.LinkColumnID,
+			ColumnID:     c.Lookup.ColumnID,
+		}
+	case entities.ColumnTypeRollup:
+		col.Rollup = &entities.ColumnRollup{
+			LinkColumnID: c.Rollup.LinkColumnID,
+			ColumnID:     c.Rollup.ColumnID,
+			Function:     c.Rollup.Function,
+		}
+		if c.Rollup.Function == entities.RollupFunctionCount {
+			col.Rollup.ColumnID = ""
+		}
+	case entities.ColumnTypeFormula:
+		col.Formula = &entities.ColumnFormula{Expression: c.Formula}
+	case entities.ColumnTypeAutonumber:
+		col.Autonumber = &entities.ColumnAutonumber{}
+		if c.Autonumber != nil {
+			col.Autonumber.Prefix = c.Autonumber.Prefix
+		}
+	}
+}
+
+// validateOptions checks the option lists of enum and multi-select columns.
+func (c *column) validateOptions() error {
+	switch c.Type {
+	case entities.ColumnTypeEnum:
+		if len(c.Enum) == 0 {
+			return fmt.Errorf("enum column must have at least one value")
+		}
+	case entities.ColumnTypeMultiSelect:
+		if len(c.Enum) == 0 {
+			return fmt.Errorf("multi-select column must have at least one option")
+		}
+		for _, option := range c.Enum {
+			if strings.TrimSpace(option) == "" || strings.Contains(option, entities.MultiSelectSeparator) {
+				return fmt.Errorf("multi-select options must not be blank or contain %q", entities.MultiSelectSeparator)
+			}
+		}
+	}
+	return nil
 }
 
 func (c *column) DistinctEnum() {
-	if c.Type != entities.ColumnTypeEnum {
+	if c.Type != entities.ColumnTypeEnum && c.Type != entities.ColumnTypeMultiSelect {
 		c.Enum = nil
 	}
 
 	seen := make(map[string]bool)
 	enum := make([]string, 0, len(c.Enum))
 	for _, v := range c.Enum {
+		// selected options are read without the spaces around them
+		if c.Type == entities.ColumnTypeMultiSelect {
+			v = strings.TrimSpace(v)
+		}
 		if seen[v] {
 			continue
 		}
@@ -52,15 +159,18 @@ func (c *column) DistinctEnum() {
 }
 
 func (c *column) toEntity() (*entities.TableColumn, error) {
-	if c.Type == entities.ColumnTypeEnum && len(c.Enum) == 0 {
-		return nil, fmt.Errorf("enum column must have at least one value")
+	if err := c.validateOptions(); err != nil {
+		return nil, err
 	}
 	c.DistinctEnum()
-	return &entities.TableColumn{
-		Name: c.Name,
-		Type: c.Type,
-		Enum: c.Enum,
-	}, nil
+	col := &entities.TableColumn{
+		Name:     c.Name,
+		Type:     c.Type,
+		Enum:     c.Enum,
+		Policies: c.policiesToEntity(),
+	}
+	c.referencesToEntity(col)
+	return col, nil
 }
 
 type columnWithID struct {
@@ -69,16 +179,19 @@ type columnWithID struct {
 }
 
 func (c *columnWithID) toEntity() (*entities.TableColumn, error) {
-	if c.Type == entities.ColumnTypeEnum && len(c.Enum) == 0 {
-		return nil, fmt.Errorf("enum column must have at least one value")
+	if err := c.validateOptions(); err != nil {
+		return nil, err
 	}
 	c.DistinctEnum()
-	return &entities.TableColumn{
-		ID:   c.ID,
-		Name: c.Name,
-		Type: c.Type,
-		Enum: c.Enum,
-	}, nil
+	col := &entities.TableColumn{
+		ID:       c.ID,
+		Name:     c.Name,
+		Type:     c.Type,
+		Enum:     c.Enum,
+		Policies: c.policiesToEntity(),
+	}
+	c.referencesToEntity(col)
+	return col, nil
 }
 
 type requestByTableID struct {
@@ -119,3 +232,92 @@ type setCellValueRequestDto struct {
 	ColumnID string  `json:"column_id" binding:"required"`
 	Value    *string `json:"value"`
 }
+
+type setUserRoleRequestDto struct {
+	TableID string `json:"table_id" binding:"required"`
+	UserID  int64  `json:"user_id" binding:"required"`
+	Role    string `json:"role" binding:"required,max=64"`
+}
+
+type deleteUserRoleRequestDto struct {
+	TableID string `json:"table_id" binding:"required"`
+	UserID  int64  `json:"user_id" binding:"required"`
+}
+
+type setRowPoliciesRequestDto struct {
+	TableID  string      `json:"table_id" binding:"required"`
+	Policies []rowPolicy `json:"policies" binding:"dive"`
+}
+
+type rowPolicy struct {
+	Role       entities.Role  `json:"role" binding:"required_without=UserID,excluded_with=UserID,omitempty,max=64,ne=admin,ne=none"`
+	UserID     *int64         `json:"user_id" binding:"omitempty,min=1"`
+	Predicates []rowPredicate `json:"predicates" binding:"required_without=OwnRows,dive"`
+	OwnRows    bool           `json:"own_rows"`
+}
+
+type rowPredicate struct {
+	ColumnID string                        `json:"column_id" binding:"required"`
+	Operator entities.RowPredicateOperator `json:"operator" binding:"required,oneof=eq neq"`
+	Value    *string                       `json:"value"`
+}
+
+func (r *setRowPoliciesRequestDto) toEntity() []*entities.RowPolicy {
+	policies := make([]*entities.RowPolicy, 0, len(r.Policies))
+	for _, policy := range r.Policies {
+		predicates := make([]*entities.RowPredicate, 0, len(policy.Predicates))
+		for _, predicate := range policy.Predicates {
+			predicates = append(predicates, &entities.RowPredicate{
+				ColumnID: predicate.ColumnID,
+				Operator: predicate.Operator,
+				Value:    predicate.Value,
+			})
+		}
+		policies = append(policies, &entities.RowPolicy{
+			Role:       policy.Role,
+			UserID:     policy.UserID,
+			Predicates: predicates,
+			OwnRows:    policy.OwnRows,
+		})
+	}
+	return policies
+}
+
+type createShareLinkRequestDto struct {
+	TableID   string     `json:"table_id" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Password  *string    `json:"password" binding:"omitempty,min=1"`
+	ColumnIDs []string   `json:"column_ids" binding:"omitempty,dive,required"`
+	Live      bool       `json:"live"`
+}
+
+func (r createShareLinkRequestDto) toEntity(userID int64) *entities.TableShareLink {
+	link := &entities.TableShareLink{
+		TableID:   r.TableID,
+		CreatedBy: userID,
+		Live:      r.Live,
+		ExpiresAt: r.ExpiresAt,
+	}
+	if len(r.ColumnIDs) > 0 {
+		link.ColumnIDs.Set(r.ColumnIDs)
+	}
+	return link
+}
+
+type revokeShareLinkRequestDto struct {
+	TableID string `json:"table_id" binding:"required"`
+	LinkID  int64  `json:"link_id" binding:"required"`
+}
+
+type moveTableRequestDto struct {
+	TableID    string `json:"table_id" binding:"required"`
+	DatabaseID int64  `json:"database_id" binding:"required"`
+}
+
+type copyTableRequestDto struct {
+	TableID       string `json:"table_id" binding:"required"`
+	DatabaseID    int64  `json:"database_id" binding:"required"`
+	Name          string `json:"name"`
+	WithData      bool   `json:"with_data"`
+	WithChangelog bool   `json:"with_changelog"`
+}
@@ -3,6 +3,7 @@ package tables
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers/common"
+	"time"
 
 	"github.com/AlekSi/pointer"
 )
@@ -50,3 +51,63 @@ func newTableWithDataResponse(table *entities.Table, rows []entities.TableRow, t
 type invalidColumValuesResponse struct {
 	InvalidValues []*string `json:"invalid_values"`
 }
+
+type tablesUserResponse struct {
+	*common.UserInfoResponse
+	Role entities.Role `json:"role"`
+}
+
+type tableUsersListResponse []*tablesUserResponse
+
+func newTableUsersListResponse(users []*entities.TablesUser) tableUsersListResponse {
+	res := make(tableUsersListResponse, 0, len(users))
+	for _, user := range users {
+		res = append(res, &tablesUserResponse{
+			UserInfoResponse: common.NewUserInfoResponse(user.User),
+			Role:             user.Role,
+		})
+	}
+	return res
+}
+
+type shareLinkResponse struct {
+	ID          int64      `json:"id"`
+	TokenPrefix string     `json:"token_prefix"`
+	HasPassword bool       `json:"has_password"`
+	ColumnIDs   []string   `json:"column_ids"`
+	Live        bool       `json:"live"`
+	CreatedBy   int64      `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+func newShareLinkResponse(link *entities.TableShareLink) *shareLinkResponse {
+	res := &shareLinkResponse{
+		ID:          link.ID,
+		TokenPrefix: link.TokenPrefix,
+		HasPassword: link.HasPassword(),
+		Live:        link.Live,
+		CreatedBy:   link.CreatedBy,
+		CreatedAt:   link.CreatedAt,
+		ExpiresAt:   link.ExpiresAt,
+	}
+	if columnIDs := link.ColumnIDs.Get(); columnIDs != nil {
+		res.ColumnIDs = *columnIDs
+	}
+	return res
+}
+
+type createdShareLinkResponse struct {
+	*shareLinkResponse
+	Token string `json:"token"`
+}
+
+type shareLinksListResponse []*shareLinkResponse
+
+func newShareLinksListResponse(links []*entities.TableShareLink) shareLinksListResponse {
+	res := make(shareLinksListResponse, 0, len(links))
+	for _, link := range links {
+		res = append(res, newShareLinkResponse(link))
+	}
+	return res
+}
@@ -3,6 +3,7 @@ package tables
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers"
+	"backend/src/handlers/common"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
 	"backend/src/services/tables"
@@ -54,13 +55,24 @@ func (h *restoreRowHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	authorized, err := h.databasesService.CheckUserRole(c, c.MustGet("user_id").(int64), table.DatabaseID, entities.RoleWriter)
+	userID := c.MustGet("user_id").(int64)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have writer role"})
+	if !permissions.Has(entities.PermissionDeleteRows) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have delete_rows permission"})
+		return
+	}
+
+	accessible, err := h.tablesService.IsRowAccessible(c, table.ForViewer(entities.TableViewer{UserID: userID, Role: role}), req.RowID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !accessible {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "row not found"})
 		return
 	}
 
@@ -71,6 +83,7 @@ func (h *restoreRowHandler) Handle(c *gin.Context) {
 	}
 
 	h.tablesHub.Broadcast(tableID, entities.EventActionFetchTable, nil)
+	common.BroadcastToLinkingTables(c, h.tablesService, h.tablesHub, table)
 	c.Status(http.StatusOK)
 }
 
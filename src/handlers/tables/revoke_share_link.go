@@ -0,0 +1,89 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/share_links"
+	"backend/src/services/tables"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type revokeShareLinkHandler struct {
+	sharedTablesHub   *web_sockets.Hub
+	tablesService     services.ITablesService
+	databasesService  services.IDatabasesService
+	shareLinksService services.IShareLinksService
+}
+
+func newRevokeShareLinkHandler(
+	sharedTablesHub *web_sockets.Hub,
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+	shareLinksService services.IShareLinksService,
+) handlers.IHandler {
+	return &revokeShareLinkHandler{
+		sharedTablesHub:   sharedTablesHub,
+		tablesService:     tablesService,
+		databasesService:  databasesService,
+		shareLinksService: shareLinksService,
+	}
+}
+
+func (h *revokeShareLinkHandler) Handle(c *gin.Context) {
+	req := revokeShareLinkRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	table, err := h.tablesService.GetTableByID(c, req.TableID, true)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserTablePermission(c, c.MustGet("user_id").(int64), table, entities.PermissionManageMembers)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have manage_members permission"})
+		return
+	}
+
+	err = h.shareLinksService.RevokeLink(c, req.TableID, req.LinkID)
+	if err != nil {
+		if share_links.IsErrShareLinkNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "share link not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	link := &entities.TableShareLink{ID: req.LinkID}
+	h.sharedTablesHub.Disconnect([]string{req.TableID}, link.WSClientID())
+
+	c.Status(http.StatusOK)
+}
+
+func (h *revokeShareLinkHandler) Path() string {
+	return "/tables/share-links/revoke"
+}
+
+func (h *revokeShareLinkHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *revokeShareLinkHandler) AuthRequired() bool {
+	return true
+}
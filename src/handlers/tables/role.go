@@ -0,0 +1,68 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type roleHandler struct {
+	tablesService    services.ITablesService
+	databasesService services.IDatabasesService
+}
+
+func newRoleHandler(
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &roleHandler{
+		tablesService:    tablesService,
+		databasesService: databasesService,
+	}
+}
+
+func (h *roleHandler) Handle(c *gin.Context) {
+	tableID := c.Param("id")
+	if tableID == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid table id"})
+		return
+	}
+
+	table, err := h.tablesService.GetTableByID(c, tableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, c.MustGet("user_id").(int64), table)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !permissions.Has(entities.PermissionRead) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"role": role, "permissions": permissions})
+}
+
+func (h *roleHandler) Path() string {
+	return "/tables/:id/role"
+}
+
+func (h *roleHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *roleHandler) AuthRequired() bool {
+	return true
+}
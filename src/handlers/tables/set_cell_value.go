@@ -3,9 +3,11 @@ package tables
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers"
+	"backend/src/handlers/common"
 	"backend/src/modules/web_sockets"
 	"backend/src/services"
 	"backend/src/services/tables"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -55,18 +57,20 @@ func (h *setCellValueHandler) Handle(c *gin.Context) {
 	}
 
 	userID := c.MustGet("user_id").(int64)
-	authorized, err := h.databasesService.CheckUserRole(c, userID, table.DatabaseID, entities.RoleWriter)
+	role, permissions, err := h.databasesService.GetUsersTablePermissions(c, userID, table)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	if !authorized {
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have writer role"})
+	if !permissions.Has(entities.PermissionWriteCells) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have write_cells permission"})
 		return
 	}
 
+	view := table.ForViewer(entities.TableViewer{UserID: userID, Role: role})
+
 	var targetColumn *entities.TableColumn
-	for _, col := range table.Columns {
+	for _, col := range view.Columns {
 		if col.ID == req.ColumnID {
 			if col.DeletedAt != nil {
 				break
@@ -79,27 +83,101 @@ func (h *setCellValueHandler) Handle(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "column not found"})
 		return
 	}
+	if targetColumn.ReadOnly {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "column is read-only"})
+		return
+	}
 
 	if !targetColumn.ValidateColumnValue(req.Value) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid value"})
 		return
 	}
+	req.Value = targetColumn.NormalizeColumnValue(req.Value)
+
+	linked, err := h.tablesService.ValidateLinkedRows(c, view, targetColumn, req.Value)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !linked {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid value"})
+		return
+	}
 
-	err = h.tablesService.SetCellValue(c, userID, table.ID, req.RowID, req.ColumnID, req.Value)
+	accessible, err := h.tablesService.IsRowAccessible(c, view, req.RowID)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if !accessible {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "row not found"})
+		return
+	}
 
-	h.tablesHub.Broadcast(tableID, entities.EventActionSetCellValue, entities.SetCellValueMessage{
+	err = h.tablesService.SetCellValue(c, userID, view, req.RowID, req.ColumnID, req.Value)
+	if err != nil {
+		if tables.IsErrRowOutsidePolicies(err) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "row would fall outside the row policies"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	common.BroadcastToLinkingTables(c, h.tablesService, h.tablesHub, table)
+
+	// link cells are read resolved together with the lookups and rollups over them,
+	// and other cells of the row may be recomputed, so the table is refetched
+	if targetColumn.Type == entities.ColumnTypeLink || table.RecomputesOnWrite() {
+		h.tablesHub.Broadcast(tableID, entities.EventActionFetchTable, nil)
+		c.Status(http.StatusOK)
+		return
+	}
+
+	message := entities.SetCellValueMessage{
 		RowID:    req.RowID,
 		ColumnID: req.ColumnID,
 		Value:    req.Value,
-	})
+	}
+	if len(targetColumn.Policies) == 0 && len(table.RowPolicies) == 0 {
+		h.tablesHub.Broadcast(tableID, entities.EventActionSetCellValue, message)
+	} else {
+		h.tablesHub.BroadcastTo(tableID, h.cellViewers(c, table, req.RowID, req.ColumnID), entities.EventActionSetCellValue, message)
+	}
 
 	c.Status(http.StatusOK)
 }
 
+// cellViewers returns the table subscribers allowed to see the cell by the column and row policies.
+func (h *setCellValueHandler) cellViewers(c *gin.Context, table *entities.Table, rowID int64, columnID string) []int64 {
+	// the request context carries the caller's token scope, which must not limit other users' roles
+	ctx := c.Request.Context()
+
+	viewers := make([]int64, 0)
+	for _, subscriberID := range h.tablesHub.Subscribers(table.ID) {
+		role, err := h.databasesService.GetUsersTableRole(ctx, subscriberID, table)
+		if err != nil {
+			log.Printf("Error resolving role of user %d: %v", subscriberID, err)
+			continue
+		}
+
+		view := table.ForViewer(entities.TableViewer{UserID: subscriberID, Role: role})
+		if !view.HasColumn(columnID) {
+			continue
+		}
+
+		accessible, err := h.tablesService.IsRowAccessible(ctx, view, rowID)
+		if err != nil {
+			log.Printf("Error checking row access of user %d: %v", subscriberID, err)
+			continue
+		}
+		if accessible {
+			viewers = append(viewers, subscriberID)
+		}
+	}
+	return viewers
+}
+
 func (h *setCellValueHandler) Path() string {
 	return "/tables/:id/set-cell-value"
 }
@@ -0,0 +1,91 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type setRowPoliciesHandler struct {
+	tablesHub        *web_sockets.Hub
+	tablesService    services.ITablesService
+	databasesService services.IDatabasesService
+}
+
+func newSetRowPoliciesHandler(
+	tablesHub *web_sockets.Hub,
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &setRowPoliciesHandler{
+		tablesHub:        tablesHub,
+		tablesService:    tablesService,
+		databasesService: databasesService,
+	}
+}
+
+func (h *setRowPoliciesHandler) Handle(c *gin.Context) {
+	req := setRowPoliciesRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	unlock := h.tablesService.LockTable(req.TableID)
+	defer unlock()
+	table, err := h.tablesService.GetTableByID(c, req.TableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserTablePermission(c, c.MustGet("user_id").(int64), table, entities.PermissionManageMembers)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have manage_members permission"})
+		return
+	}
+
+	table, err = h.tablesService.SetRowPolicies(c, req.TableID, req.toEntity())
+	if err != nil {
+		if tables.IsErrColumnNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "column not found"})
+			return
+		}
+		if tables.IsErrComputedRowPredicate(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.tablesHub.Broadcast(req.TableID, entities.EventActionFetchTable, nil)
+
+	c.JSON(http.StatusOK, common.NewTableResponse(table))
+}
+
+func (h *setRowPoliciesHandler) Path() string {
+	return "/tables/set-row-policies"
+}
+
+func (h *setRowPoliciesHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *setRowPoliciesHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,109 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"backend/src/services/tables"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type setUserRoleHandler struct {
+	tablesService    services.ITablesService
+	databasesService services.IDatabasesService
+	tablesHub        *web_sockets.Hub
+	usersHub         *web_sockets.Hub
+}
+
+func newSetUserRoleHandler(
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &setUserRoleHandler{
+		tablesService:    tablesService,
+		databasesService: databasesService,
+		tablesHub:        tablesHub,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *setUserRoleHandler) Handle(c *gin.Context) {
+	req := setUserRoleRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	table, err := h.tablesService.GetTableByID(c, req.TableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// table roles are managed at the database level, so a table admin cannot hand the table out
+	userID := c.MustGet("user_id").(int64)
+	authorized, err := h.databasesService.CanManageMember(c, userID, table.DatabaseID, req.UserID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user cannot manage this member"})
+		return
+	}
+
+	authorized, err = h.databasesService.CanGrantRole(c, userID, table.DatabaseID, entities.Role(req.Role))
+	if err != nil {
+		if databases.IsErrRoleNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "role not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user cannot grant this role"})
+		return
+	}
+
+	_, err = h.databasesService.SetUsersTableRole(c, req.UserID, table, entities.Role(req.Role))
+	if err != nil {
+		if databases.IsErrNotMember(err) || databases.IsErrTableRoleForAdmin(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if entities.Role(req.Role) == entities.RoleNone {
+		common.ThrowUserFromTables(h.tablesHub, h.usersHub, req.UserID, []string{table.ID})
+	}
+	h.usersHub.Broadcast(strconv.FormatInt(req.UserID, 10), entities.EventActionFetchDatabases, nil)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *setUserRoleHandler) Path() string {
+	return "/tables/set-user-role"
+}
+
+func (h *setUserRoleHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *setUserRoleHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,77 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type shareLinksHandler struct {
+	tablesService     services.ITablesService
+	databasesService  services.IDatabasesService
+	shareLinksService services.IShareLinksService
+}
+
+func newShareLinksHandler(
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+	shareLinksService services.IShareLinksService,
+) handlers.IHandler {
+	return &shareLinksHandler{
+		tablesService:     tablesService,
+		databasesService:  databasesService,
+		shareLinksService: shareLinksService,
+	}
+}
+
+func (h *shareLinksHandler) Handle(c *gin.Context) {
+	tableID := c.Param("id")
+	if tableID == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid table id"})
+		return
+	}
+
+	table, err := h.tablesService.GetTableByID(c, tableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserTablePermission(c, c.MustGet("user_id").(int64), table, entities.PermissionManageMembers)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have manage_members permission"})
+		return
+	}
+
+	links, err := h.shareLinksService.ListTableLinks(c, tableID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newShareLinksListResponse(links))
+}
+
+func (h *shareLinksHandler) Path() string {
+	return "/tables/:id/share-links"
+}
+
+func (h *shareLinksHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *shareLinksHandler) AuthRequired() bool {
+	return true
+}
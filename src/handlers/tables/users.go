@@ -0,0 +1,74 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type usersHandler struct {
+	tablesService    services.ITablesService
+	databasesService services.IDatabasesService
+}
+
+func newUsersHandler(
+	tablesService services.ITablesService,
+	databasesService services.IDatabasesService,
+) handlers.IHandler {
+	return &usersHandler{
+		tablesService:    tablesService,
+		databasesService: databasesService,
+	}
+}
+
+func (h *usersHandler) Handle(c *gin.Context) {
+	tableID := c.Param("id")
+	if tableID == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid table id"})
+		return
+	}
+
+	table, err := h.tablesService.GetTableByID(c, tableID, false)
+	if err != nil {
+		if tables.IsErrTableNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "table not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	authorized, err := h.databasesService.CheckUserTablePermission(c, c.MustGet("user_id").(int64), table, entities.PermissionRead)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !authorized {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user does not have read permission"})
+		return
+	}
+
+	tablesUsers, err := h.databasesService.GetTablesUsers(c, table.ID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTableUsersListResponse(tablesUsers))
+}
+
+func (h *usersHandler) Path() string {
+	return "/tables/:id/users"
+}
+
+func (h *usersHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *usersHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,80 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/users"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type changeEmailHandler struct {
+	userService services.IUsersService
+}
+
+func newChangeEmailHandler(
+	userService services.IUsersService,
+) handlers.IHandler {
+	return &changeEmailHandler{
+		userService: userService,
+	}
+}
+
+func (h *changeEmailHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := changeEmailRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	user, err := h.userService.FindUserByID(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.userService.RequestEmailChange(c, user, req.Password, req.Email)
+	if err != nil {
+		switch {
+		case users.IsErrWrongPassword(err):
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case users.IsErrEmailAlreadyTaken(err):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *changeEmailHandler) Path() string {
+	return "/users/profile/change-email"
+}
+
+func (h *changeEmailHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *changeEmailHandler) AuthRequired() bool {
+	return true
+}
+
+func (h *changeEmailHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "change-email", KeyBy: rate_limiter.KeyByUser, Limit: rate_limiter.Limit{Requests: 5, Window: time.Hour}},
+	}
+}
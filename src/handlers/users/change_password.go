@@ -0,0 +1,93 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/password_hasher"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/users"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type changePasswordHandler struct {
+	authService services.IAuthService
+	userService services.IUsersService
+}
+
+func newChangePasswordHandler(
+	authService services.IAuthService,
+	userService services.IUsersService,
+) handlers.IHandler {
+	return &changePasswordHandler{
+		authService: authService,
+		userService: userService,
+	}
+}
+
+func (h *changePasswordHandler) Handle(c *gin.Context) {
+	sessionID, ok := common.RequireSession(c)
+	if !ok {
+		return
+	}
+
+	req := changePasswordRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	user, err := h.userService.FindUserByID(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.userService.ChangePassword(c, user, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		if users.IsErrWrongPassword(err) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if password_hasher.IsErrPasswordTooLong(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Other devices have to sign in with the new password.
+	err = h.authService.LogoutOthers(c, user.ID, sessionID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *changePasswordHandler) Path() string {
+	return "/users/profile/change-password"
+}
+
+func (h *changePasswordHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *changePasswordHandler) AuthRequired() bool {
+	return true
+}
+
+func (h *changePasswordHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "change-password", KeyBy: rate_limiter.KeyByUser, Limit: rate_limiter.Limit{Requests: 10, Window: time.Hour}},
+	}
+}
@@ -0,0 +1,74 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/users"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type confirmEmailChangeHandler struct {
+	userService        services.IUsersService
+	invitationsService services.IInvitationsService
+}
+
+func newConfirmEmailChangeHandler(
+	userService services.IUsersService,
+	invitationsService services.IInvitationsService,
+) handlers.IHandler {
+	return &confirmEmailChangeHandler{
+		userService:        userService,
+		invitationsService: invitationsService,
+	}
+}
+
+func (h *confirmEmailChangeHandler) Handle(c *gin.Context) {
+	req := confirmEmailChangeRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	user, err := h.userService.ConfirmEmailChange(c, req.Token)
+	if err != nil {
+		switch {
+		case users.IsErrInvalidToken(err):
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case users.IsErrEmailAlreadyTaken(err):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if _, err := h.invitationsService.LinkInvitations(c, user); err != nil {
+		log.Printf("Error linking invitations to user %d: %v", user.ID, err)
+	}
+
+	c.JSON(http.StatusOK, common.NewUserInfoResponse(user))
+}
+
+func (h *confirmEmailChangeHandler) Path() string {
+	return "/users/confirm-email-change"
+}
+
+func (h *confirmEmailChangeHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *confirmEmailChangeHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *confirmEmailChangeHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "confirm-email-change", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 20, Window: time.Hour}},
+	}
+}
@@ -0,0 +1,62 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createTokenHandler struct {
+	authService services.IAuthService
+}
+
+func newCreateTokenHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &createTokenHandler{
+		authService: authService,
+	}
+}
+
+func (h *createTokenHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := createTokenRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+		return
+	}
+
+	token, tokenString, err := h.authService.CreatePersonalAccessToken(c, req.ToPersonalAccessToken(c.MustGet("user_id").(int64)))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, &createdPersonalAccessTokenResponse{
+		personalAccessTokenResponse: newPersonalAccessTokenResponse(token),
+		Token:                       tokenString,
+	})
+}
+
+func (h *createTokenHandler) Path() string {
+	return "/users/tokens/create"
+}
+
+func (h *createTokenHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *createTokenHandler) AuthRequired() bool {
+	return true
+}
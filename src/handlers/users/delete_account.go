@@ -0,0 +1,120 @@
+package users
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/rate_limiter"
+	"backend/src/modules/web_sockets"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"backend/src/services/organizations"
+	"backend/src/services/users"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteAccountHandler struct {
+	userService      services.IUsersService
+	databasesService services.IDatabasesService
+	tablesService    services.ITablesService
+	tablesHub        *web_sockets.Hub
+	usersHub         *web_sockets.Hub
+}
+
+func newDeleteAccountHandler(
+	userService services.IUsersService,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
+) handlers.IHandler {
+	return &deleteAccountHandler{
+		userService:      userService,
+		databasesService: databasesService,
+		tablesService:    tablesService,
+		tablesHub:        tablesHub,
+		usersHub:         usersHub,
+	}
+}
+
+func (h *deleteAccountHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := deleteAccountRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	user, err := h.userService.FindUserByID(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	usersDatabases, err := h.databasesService.GetUsersDatabases(c, user.ID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.userService.DeleteUser(c, user, req.Password)
+	if err != nil {
+		if blocking, ok := databases.IsErrCannotLeaveDatabases(err); ok {
+			databaseIDs := make([]int64, 0, len(blocking.Databases))
+			for _, db := range blocking.Databases {
+				databaseIDs = append(databaseIDs, db.ID)
+			}
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error(), "database_ids": databaseIDs})
+			return
+		}
+		if blocking, ok := organizations.IsErrCannotLeaveOrganizations(err); ok {
+			organizationIDs := make([]int64, 0, len(blocking.Organizations))
+			for _, organization := range blocking.Organizations {
+				organizationIDs = append(organizationIDs, organization.OrganizationID)
+			}
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error(), "organization_ids": organizationIDs})
+			return
+		}
+		if users.IsErrWrongPassword(err) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, usersDatabase := range usersDatabases {
+		_ = common.ThrowUserFromDBTables(c, h.tablesService, h.tablesHub, h.usersHub, user.ID, usersDatabase.DatabaseID)
+		_ = common.SendActionToDBUsers(c, h.databasesService, h.usersHub, usersDatabase.DatabaseID, entities.EventActionFetchDatabases)
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *deleteAccountHandler) Path() string {
+	return "/users/delete"
+}
+
+func (h *deleteAccountHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *deleteAccountHandler) AuthRequired() bool {
+	return true
+}
+
+func (h *deleteAccountHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "delete-account", KeyBy: rate_limiter.KeyByUser, Limit: rate_limiter.Limit{Requests: 5, Window: time.Hour}},
+	}
+}
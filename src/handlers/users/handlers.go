@@ -2,17 +2,42 @@ package users
 
 import (
 	"backend/src/handlers"
+	"backend/src/modules/web_sockets"
 	"backend/src/services"
 )
 
 func NewHandlers(
 	authService services.IAuthService,
 	userService services.IUsersService,
+	invitationsService services.IInvitationsService,
+	databasesService services.IDatabasesService,
+	tablesService services.ITablesService,
+	tablesHub *web_sockets.Hub,
+	usersHub *web_sockets.Hub,
 ) []handlers.IHandler {
 	return []handlers.IHandler{
 		newLoginHandler(authService),
-		newRegisterHandler(authService, userService),
+		newOIDCLoginHandler(authService),
+		newOIDCCallbackHandler(authService, invitationsService),
+		newRegisterHandler(authService, userService, invitationsService),
 		newInfoHandler(userService),
-		newListHandler(userService),
+		newSearchHandler(userService),
+		newRefreshHandler(authService),
+		newLogoutHandler(authService),
+		newLogoutAllHandler(authService),
+		newSessionsHandler(authService),
+		newRevokeSessionHandler(authService),
+		newTokensHandler(authService),
+		newCreateTokenHandler(authService),
+		newRevokeTokenHandler(authService),
+		newRequestPasswordResetHandler(userService),
+		newResetPasswordHandler(authService, userService, invitationsService),
+		newVerifyEmailHandler(userService, invitationsService),
+		newSendVerificationHandler(userService),
+		newUpdateProfileHandler(userService),
+		newChangeEmailHandler(userService),
+		newConfirmEmailChangeHandler(userService, invitationsService),
+		newChangePasswordHandler(authService, userService),
+		newDeleteAccountHandler(userService, databasesService, tablesService, tablesHub, usersHub),
 	}
 }
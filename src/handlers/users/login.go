@@ -2,8 +2,12 @@ package users
 
 import (
 	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/rate_limiter"
 	"backend/src/services"
+	"backend/src/services/auth"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,13 +31,17 @@ func (h *loginHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	user, token, err := h.authService.Login(c, req.Email, req.Password)
+	user, tokens, err := h.authService.Login(c, req.Email, req.Password, common.NewSessionClient(c))
 	if err != nil {
+		if lockout, ok := auth.IsErrTooManyLoginAttempts(err); ok {
+			rate_limiter.AbortWithTooManyRequests(c, rate_limiter.Result{RetryAfter: lockout.RetryAfter})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, newLoginResponse(token, user))
+	c.JSON(http.StatusOK, newLoginResponse(tokens, user))
 }
 
 func (h *loginHandler) Path() string {
@@ -47,3 +55,9 @@ func (h *loginHandler) Method() string {
 func (h *loginHandler) AuthRequired() bool {
 	return false
 }
+
+func (h *loginHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "login", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 20, Window: time.Minute}},
+	}
+}
@@ -0,0 +1,49 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type logoutHandler struct {
+	authService services.IAuthService
+}
+
+func newLogoutHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &logoutHandler{
+		authService: authService,
+	}
+}
+
+func (h *logoutHandler) Handle(c *gin.Context) {
+	sessionID, ok := common.RequireSession(c)
+	if !ok {
+		return
+	}
+
+	err := h.authService.Logout(c, sessionID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *logoutHandler) Path() string {
+	return "/users/logout"
+}
+
+func (h *logoutHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *logoutHandler) AuthRequired() bool {
+	return true
+}
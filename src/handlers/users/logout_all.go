@@ -0,0 +1,48 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type logoutAllHandler struct {
+	authService services.IAuthService
+}
+
+func newLogoutAllHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &logoutAllHandler{
+		authService: authService,
+	}
+}
+
+func (h *logoutAllHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	err := h.authService.LogoutAll(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *logoutAllHandler) Path() string {
+	return "/users/logout-all"
+}
+
+func (h *logoutAllHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *logoutAllHandler) AuthRequired() bool {
+	return true
+}
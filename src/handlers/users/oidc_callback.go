@@ -0,0 +1,80 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/oidc"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/auth"
+	"backend/src/services/users"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type oidcCallbackHandler struct {
+	authService        services.IAuthService
+	invitationsService services.IInvitationsService
+}
+
+func newOIDCCallbackHandler(
+	authService services.IAuthService,
+	invitationsService services.IInvitationsService,
+) handlers.IHandler {
+	return &oidcCallbackHandler{
+		authService:        authService,
+		invitationsService: invitationsService,
+	}
+}
+
+func (h *oidcCallbackHandler) Handle(c *gin.Context) {
+	req := oidcCallbackRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	user, tokens, err := h.authService.CompleteOIDCLogin(c, req.State, req.Code, common.NewSessionClient(c))
+	if err != nil {
+		switch {
+		case auth.IsErrOIDCDisabled(err):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case auth.IsErrInvalidOIDCState(err), users.IsErrEmailRequired(err):
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case oidc.IsErrInvalidIDToken(err), users.IsErrUserNotFound(err):
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case users.IsErrEmailAlreadyTaken(err):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if _, err := h.invitationsService.LinkInvitations(c, user); err != nil {
+		log.Printf("Error linking invitations to user %d: %v", user.ID, err)
+	}
+
+	c.JSON(http.StatusOK, newLoginResponse(tokens, user))
+}
+
+func (h *oidcCallbackHandler) Path() string {
+	return "/users/oidc/callback"
+}
+
+func (h *oidcCallbackHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *oidcCallbackHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *oidcCallbackHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "oidc-callback", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 20, Window: time.Minute}},
+	}
+}
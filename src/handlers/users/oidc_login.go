@@ -0,0 +1,56 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/auth"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type oidcLoginHandler struct {
+	authService services.IAuthService
+}
+
+func newOIDCLoginHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &oidcLoginHandler{
+		authService: authService,
+	}
+}
+
+func (h *oidcLoginHandler) Handle(c *gin.Context) {
+	authURL, err := h.authService.StartOIDCLogin(c)
+	if err != nil {
+		if auth.IsErrOIDCDisabled(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, oidcLoginResponse{AuthorizationURL: authURL})
+}
+
+func (h *oidcLoginHandler) Path() string {
+	return "/users/oidc/login"
+}
+
+func (h *oidcLoginHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *oidcLoginHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *oidcLoginHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "oidc-login", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 20, Window: time.Minute}},
+	}
+}
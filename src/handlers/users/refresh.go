@@ -0,0 +1,63 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/auth"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type refreshHandler struct {
+	authService services.IAuthService
+}
+
+func newRefreshHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &refreshHandler{
+		authService: authService,
+	}
+}
+
+func (h *refreshHandler) Handle(c *gin.Context) {
+	req := refreshRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	tokens, err := h.authService.Refresh(c, req.RefreshToken, common.NewSessionClient(c))
+	if err != nil {
+		if auth.IsErrInvalidRefreshToken(err) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTokensResponse(tokens))
+}
+
+func (h *refreshHandler) Path() string {
+	return "/users/refresh"
+}
+
+func (h *refreshHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *refreshHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *refreshHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "refresh", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 60, Window: time.Minute}},
+	}
+}
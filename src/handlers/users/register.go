@@ -2,24 +2,33 @@ package users
 
 import (
 	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/password_hasher"
+	"backend/src/modules/rate_limiter"
 	"backend/src/services"
+	"backend/src/services/auth"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type registerHandler struct {
-	authService services.IAuthService
-	userService services.IUsersService
+	authService        services.IAuthService
+	userService        services.IUsersService
+	invitationsService services.IInvitationsService
 }
 
 func newRegisterHandler(
 	authService services.IAuthService,
 	userService services.IUsersService,
+	invitationsService services.IInvitationsService,
 ) handlers.IHandler {
 	return &registerHandler{
-		authService: authService,
-		userService: userService,
+		authService:        authService,
+		userService:        userService,
+		invitationsService: invitationsService,
 	}
 }
 
@@ -32,17 +41,33 @@ func (h *registerHandler) Handle(c *gin.Context) {
 
 	user, err := h.userService.AddUser(c, req.ToUser())
 	if err != nil {
+		if password_hasher.IsErrPasswordTooLong(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	user, token, err := h.authService.Login(c, req.Email, req.Password)
+	if err := h.userService.SendEmailVerification(c, user); err != nil {
+		log.Printf("Error sending email verification to user %d: %v", user.ID, err)
+	}
+
+	if _, err := h.invitationsService.LinkInvitations(c, user); err != nil {
+		log.Printf("Error linking invitations to user %d: %v", user.ID, err)
+	}
+
+	user, tokens, err := h.authService.Login(c, req.Email, req.Password, common.NewSessionClient(c))
 	if err != nil {
+		if lockout, ok := auth.IsErrTooManyLoginAttempts(err); ok {
+			rate_limiter.AbortWithTooManyRequests(c, rate_limiter.Result{RetryAfter: lockout.RetryAfter})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, newLoginResponse(token, user))
+	c.JSON(http.StatusOK, newLoginResponse(tokens, user))
 }
 
 func (h *registerHandler) Path() string {
@@ -56,3 +81,9 @@ func (h *registerHandler) Method() string {
 func (h *registerHandler) AuthRequired() bool {
 	return false
 }
+
+func (h *registerHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "register", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 10, Window: time.Hour}},
+	}
+}
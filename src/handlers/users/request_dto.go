@@ -1,12 +1,32 @@
 package users
 
-import "backend/src/domains/entities"
+import (
+	"backend/src/domains/entities"
+	"time"
+)
 
 type loginRequestDto struct {
 	Email    string `json:"email" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
 
+type refreshRequestDto struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type requestPasswordResetRequestDto struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type resetPasswordRequestDto struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type verifyEmailRequestDto struct {
+	Token string `json:"token" binding:"required"`
+}
+
 type registerRequestDto struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required"`
@@ -20,3 +40,50 @@ func (r registerRequestDto) ToUser() *entities.User {
 		Password: r.Password,
 	}
 }
+
+type createTokenRequestDto struct {
+	Name        string     `json:"name" binding:"required"`
+	DatabaseIDs []int64    `json:"database_ids" binding:"omitempty,dive,min=1"`
+	Access      string     `json:"access" binding:"required,oneof=read write"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+func (r createTokenRequestDto) ToPersonalAccessToken(userID int64) *entities.PersonalAccessToken {
+	token := &entities.PersonalAccessToken{
+		UserID:    userID,
+		Name:      r.Name,
+		Access:    entities.TokenAccess(r.Access),
+		ExpiresAt: r.ExpiresAt,
+	}
+	if len(r.DatabaseIDs) > 0 {
+		token.DatabaseIDs.Set(r.DatabaseIDs)
+	}
+	return token
+}
+
+type oidcCallbackRequestDto struct {
+	State string `json:"state" binding:"required"`
+	Code  string `json:"code" binding:"required"`
+}
+
+type updateProfileRequestDto struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type changeEmailRequestDto struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type confirmEmailChangeRequestDto struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type changePasswordRequestDto struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type deleteAccountRequestDto struct {
+	Password string `json:"password" binding:"required"`
+}
@@ -0,0 +1,59 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/users"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type requestPasswordResetHandler struct {
+	userService services.IUsersService
+}
+
+func newRequestPasswordResetHandler(
+	userService services.IUsersService,
+) handlers.IHandler {
+	return &requestPasswordResetHandler{
+		userService: userService,
+	}
+}
+
+func (h *requestPasswordResetHandler) Handle(c *gin.Context) {
+	req := requestPasswordResetRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	// The response is the same whether the user exists or not, so that emails can't be enumerated.
+	err := h.userService.RequestPasswordReset(c, req.Email)
+	if err != nil && !users.IsErrUserNotFound(err) {
+		log.Printf("Error requesting password reset: %v", err)
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *requestPasswordResetHandler) Path() string {
+	return "/users/request-password-reset"
+}
+
+func (h *requestPasswordResetHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *requestPasswordResetHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *requestPasswordResetHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "request-password-reset", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 5, Window: time.Hour}},
+	}
+}
@@ -0,0 +1,85 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/modules/password_hasher"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/users"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type resetPasswordHandler struct {
+	authService        services.IAuthService
+	userService        services.IUsersService
+	invitationsService services.IInvitationsService
+}
+
+func newResetPasswordHandler(
+	authService services.IAuthService,
+	userService services.IUsersService,
+	invitationsService services.IInvitationsService,
+) handlers.IHandler {
+	return &resetPasswordHandler{
+		authService:        authService,
+		userService:        userService,
+		invitationsService: invitationsService,
+	}
+}
+
+func (h *resetPasswordHandler) Handle(c *gin.Context) {
+	req := resetPasswordRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	user, err := h.userService.ResetPassword(c, req.Token, req.Password)
+	if err != nil {
+		if users.IsErrInvalidToken(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if password_hasher.IsErrPasswordTooLong(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.authService.LogoutAll(c, user.ID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Resetting the password verifies the email too.
+	if _, err := h.invitationsService.LinkInvitations(c, user); err != nil {
+		log.Printf("Error linking invitations to user %d: %v", user.ID, err)
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *resetPasswordHandler) Path() string {
+	return "/users/reset-password"
+}
+
+func (h *resetPasswordHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *resetPasswordHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *resetPasswordHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "reset-password", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 20, Window: time.Hour}},
+	}
+}
@@ -3,26 +3,118 @@ package users
 import (
 	"backend/src/domains/entities"
 	"backend/src/handlers/common"
+	"time"
 )
 
+type tokensResponse struct {
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+func newTokensResponse(tokens *entities.AuthTokens) *tokensResponse {
+	return &tokensResponse{
+		Token:        tokens.AccessToken,
+		ExpiresAt:    tokens.AccessTokenExpiresAt,
+		RefreshToken: tokens.RefreshToken,
+	}
+}
+
 type loginResponse struct {
-	Token    string                   `json:"token"`
+	*tokensResponse
 	UserInfo *common.UserInfoResponse `json:"user_info"`
 }
 
-func newLoginResponse(token string, user *entities.User) *loginResponse {
+func newLoginResponse(tokens *entities.AuthTokens, user *entities.User) *loginResponse {
 	return &loginResponse{
-		Token:    token,
-		UserInfo: common.NewUserInfoResponse(user),
+		tokensResponse: newTokensResponse(tokens),
+		UserInfo:       common.NewUserInfoResponse(user),
 	}
 }
 
-type usersListResponse []*common.UserInfoResponse
+type usersSearchResponse struct {
+	Users []*common.UserInfoResponse `json:"users"`
+	Total int64                      `json:"total"`
+}
 
-func newUsersListResponse(users []*entities.User) usersListResponse {
-	res := make(usersListResponse, 0, len(users))
+func newUsersSearchResponse(users []*entities.User, total int64) *usersSearchResponse {
+	res := &usersSearchResponse{
+		Users: make([]*common.UserInfoResponse, 0, len(users)),
+		Total: total,
+	}
 	for _, user := range users {
-		res = append(res, common.NewUserInfoResponse(user))
+		res.Users = append(res.Users, common.NewUserInfoResponse(user))
+	}
+	return res
+}
+
+type sessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type sessionsListResponse []*sessionResponse
+
+func newSessionsListResponse(sessions []*entities.Session, currentSessionID string) sessionsListResponse {
+	res := make(sessionsListResponse, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, &sessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
 	}
 	return res
 }
+
+type personalAccessTokenResponse struct {
+	ID          int64                `json:"id"`
+	Name        string               `json:"name"`
+	TokenPrefix string               `json:"token_prefix"`
+	DatabaseIDs []int64              `json:"database_ids"`
+	Access      entities.TokenAccess `json:"access"`
+	CreatedAt   time.Time            `json:"created_at"`
+	LastUsedAt  *time.Time           `json:"last_used_at"`
+	ExpiresAt   *time.Time           `json:"expires_at"`
+}
+
+func newPersonalAccessTokenResponse(token *entities.PersonalAccessToken) *personalAccessTokenResponse {
+	return &personalAccessTokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		DatabaseIDs: token.Scope().DatabaseIDs,
+		Access:      token.Access,
+		CreatedAt:   token.CreatedAt,
+		LastUsedAt:  token.LastUsedAt,
+		ExpiresAt:   token.ExpiresAt,
+	}
+}
+
+type createdPersonalAccessTokenResponse struct {
+	*personalAccessTokenResponse
+	Token string `json:"token"`
+}
+
+type personalAccessTokensListResponse []*personalAccessTokenResponse
+
+func newPersonalAccessTokensListResponse(tokens []*entities.PersonalAccessToken) personalAccessTokensListResponse {
+	res := make(personalAccessTokensListResponse, 0, len(tokens))
+	for _, token := range tokens {
+		res = append(res, newPersonalAccessTokenResponse(token))
+	}
+	return res
+}
+
+type oidcLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
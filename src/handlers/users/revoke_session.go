@@ -0,0 +1,59 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"backend/src/services/auth"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type revokeSessionHandler struct {
+	authService services.IAuthService
+}
+
+func newRevokeSessionHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &revokeSessionHandler{
+		authService: authService,
+	}
+}
+
+func (h *revokeSessionHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	err := h.authService.RevokeSession(c, c.MustGet("user_id").(int64), sessionID)
+	if err != nil {
+		if auth.IsErrSessionNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "session not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *revokeSessionHandler) Path() string {
+	return "/users/sessions/:id/revoke"
+}
+
+func (h *revokeSessionHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *revokeSessionHandler) AuthRequired() bool {
+	return true
+}
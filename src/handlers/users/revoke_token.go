@@ -0,0 +1,60 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"backend/src/services/auth"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type revokeTokenHandler struct {
+	authService services.IAuthService
+}
+
+func newRevokeTokenHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &revokeTokenHandler{
+		authService: authService,
+	}
+}
+
+func (h *revokeTokenHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	tokenID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid token ID: " + err.Error()})
+		return
+	}
+
+	err = h.authService.RevokePersonalAccessToken(c, c.MustGet("user_id").(int64), tokenID)
+	if err != nil {
+		if auth.IsErrPersonalAccessTokenNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "token not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *revokeTokenHandler) Path() string {
+	return "/users/tokens/:id/revoke"
+}
+
+func (h *revokeTokenHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *revokeTokenHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,50 @@
+package users
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/handlers"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type searchHandler struct {
+	userService services.IUsersService
+}
+
+func newSearchHandler(
+	userService services.IUsersService,
+) handlers.IHandler {
+	return &searchHandler{
+		userService: userService,
+	}
+}
+
+func (h *searchHandler) Handle(c *gin.Context) {
+	var q entities.SearchUsersParams
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	usersList, total, err := h.userService.SearchUsers(c, c.MustGet("user_id").(int64), q)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newUsersSearchResponse(usersList, total))
+}
+
+func (h *searchHandler) Path() string {
+	return "/users/search"
+}
+
+func (h *searchHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *searchHandler) AuthRequired() bool {
+	return true
+}
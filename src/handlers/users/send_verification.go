@@ -0,0 +1,70 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/users"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type sendVerificationHandler struct {
+	userService services.IUsersService
+}
+
+func newSendVerificationHandler(
+	userService services.IUsersService,
+) handlers.IHandler {
+	return &sendVerificationHandler{
+		userService: userService,
+	}
+}
+
+func (h *sendVerificationHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	user, err := h.userService.FindUserByID(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if user.EmailVerifiedAt != nil {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "email is already verified"})
+		return
+	}
+
+	if err := h.userService.SendEmailVerification(c, user); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *sendVerificationHandler) Path() string {
+	return "/users/send-verification"
+}
+
+func (h *sendVerificationHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *sendVerificationHandler) AuthRequired() bool {
+	return true
+}
+
+func (h *sendVerificationHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "send-verification", KeyBy: rate_limiter.KeyByUser, Limit: rate_limiter.Limit{Requests: 5, Window: time.Hour}},
+	}
+}
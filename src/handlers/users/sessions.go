@@ -0,0 +1,49 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type sessionsHandler struct {
+	authService services.IAuthService
+}
+
+func newSessionsHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &sessionsHandler{
+		authService: authService,
+	}
+}
+
+func (h *sessionsHandler) Handle(c *gin.Context) {
+	sessionID, ok := common.RequireSession(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newSessionsListResponse(sessions, sessionID))
+}
+
+func (h *sessionsHandler) Path() string {
+	return "/users/sessions"
+}
+
+func (h *sessionsHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *sessionsHandler) AuthRequired() bool {
+	return true
+}
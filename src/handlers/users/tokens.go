@@ -0,0 +1,48 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type tokensHandler struct {
+	authService services.IAuthService
+}
+
+func newTokensHandler(
+	authService services.IAuthService,
+) handlers.IHandler {
+	return &tokensHandler{
+		authService: authService,
+	}
+}
+
+func (h *tokensHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	tokens, err := h.authService.ListPersonalAccessTokens(c, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newPersonalAccessTokensListResponse(tokens))
+}
+
+func (h *tokensHandler) Path() string {
+	return "/users/tokens"
+}
+
+func (h *tokensHandler) Method() string {
+	return http.MethodGet
+}
+
+func (h *tokensHandler) AuthRequired() bool {
+	return true
+}
@@ -0,0 +1,59 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/services"
+	"backend/src/services/users"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type updateProfileHandler struct {
+	userService services.IUsersService
+}
+
+func newUpdateProfileHandler(
+	userService services.IUsersService,
+) handlers.IHandler {
+	return &updateProfileHandler{
+		userService: userService,
+	}
+}
+
+func (h *updateProfileHandler) Handle(c *gin.Context) {
+	if _, ok := common.RequireSession(c); !ok {
+		return
+	}
+
+	req := updateProfileRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	user, err := h.userService.UpdateName(c, c.MustGet("user_id").(int64), req.Name)
+	if err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewUserInfoResponse(user))
+}
+
+func (h *updateProfileHandler) Path() string {
+	return "/users/profile/update"
+}
+
+func (h *updateProfileHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *updateProfileHandler) AuthRequired() bool {
+	return true
+}
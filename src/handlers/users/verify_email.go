@@ -0,0 +1,71 @@
+package users
+
+import (
+	"backend/src/handlers"
+	"backend/src/handlers/common"
+	"backend/src/modules/rate_limiter"
+	"backend/src/services"
+	"backend/src/services/users"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type verifyEmailHandler struct {
+	userService        services.IUsersService
+	invitationsService services.IInvitationsService
+}
+
+func newVerifyEmailHandler(
+	userService services.IUsersService,
+	invitationsService services.IInvitationsService,
+) handlers.IHandler {
+	return &verifyEmailHandler{
+		userService:        userService,
+		invitationsService: invitationsService,
+	}
+}
+
+func (h *verifyEmailHandler) Handle(c *gin.Context) {
+	req := verifyEmailRequestDto{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	user, err := h.userService.VerifyEmail(c, req.Token)
+	if err != nil {
+		if users.IsErrInvalidToken(err) || users.IsErrUserNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid or expired token"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.invitationsService.LinkInvitations(c, user); err != nil {
+		log.Printf("Error linking invitations to user %d: %v", user.ID, err)
+	}
+
+	c.JSON(http.StatusOK, common.NewUserInfoResponse(user))
+}
+
+func (h *verifyEmailHandler) Path() string {
+	return "/users/verify-email"
+}
+
+func (h *verifyEmailHandler) Method() string {
+	return http.MethodPost
+}
+
+func (h *verifyEmailHandler) AuthRequired() bool {
+	return false
+}
+
+func (h *verifyEmailHandler) RateLimits() []rate_limiter.Rule {
+	return []rate_limiter.Rule{
+		{Name: "verify-email", KeyBy: rate_limiter.KeyByIP, Limit: rate_limiter.Limit{Requests: 20, Window: time.Hour}},
+	}
+}
@@ -0,0 +1,345 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type compiler struct {
+	resolve Resolver
+}
+
+func (c *compiler) compileNode(n node) (*Operand, error) {
+	switch n := n.(type) {
+	case *numberNode:
+		return &Operand{SQL: n.value + "::numeric", Type: TypeNumber}, nil
+
+	case *stringNode:
+		return &Operand{SQL: Quote(n.value), Type: TypeText}, nil
+
+	case *booleanNode:
+		if n.value {
+			return &Operand{SQL: "true", Type: TypeBoolean}, nil
+		}
+		return &Operand{SQL: "false", Type: TypeBoolean}, nil
+
+	case *referenceNode:
+		resolved, err := c.resolve(n.ref)
+		if err != nil {
+			// errors of referenced formulas are reported at the reference
+			var formulaErr *Error
+			if errors.As(err, &formulaErr) {
+				return nil, newError(n.pos, "%s", formulaErr.Message)
+			}
+			return nil, newError(n.pos, "%s", err.Error())
+		}
+		return &Operand{SQL: "(" + resolved.SQL + ")", Type: resolved.Type}, nil
+
+	case *unaryNode:
+		operand, err := c.compileNode(n.operand)
+		if err != nil {
+			return nil, err
+		}
+		if operand.Type != TypeNumber {
+			return nil, newError(n.pos, "- expects a number, got %s", operand.Type)
+		}
+		return number("(-" + operand.SQL + ")"), nil
+
+	case *binaryNode:
+		return c.compileBinary(n)
+
+	case *callNode:
+		return c.compileCall(n)
+
+	default:
+		return nil, newError(n.position(), "unsupported expression")
+	}
+}
+
+func (c *compiler) compileBinary(n *binaryNode) (*Operand, error) {
+	left, err := c.compileNode(n.left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.compileNode(n.right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "&":
+		return text(fmt.Sprintf("concat(%s, %s)", textSQL(left), textSQL(right))), nil
+
+	case "*", "/":
+		if left.Type != TypeNumber || right.Type != TypeNumber {
+			return nil, newError(n.pos, "%s expects numbers, got %s and %s", n.op, left.Type, right.Type)
+		}
+		if n.op == "/" {
+			return number(fmt.Sprintf("(%s / nullif(%s, 0))", left.SQL, right.SQL)), nil
+		}
+		return number(fmt.Sprintf("(%s * %s)", left.SQL, right.SQL)), nil
+
+	case "+", "-":
+		return c.compileArithmetic(n, left, right)
+
+	default:
+		left, right = unifyTimes(left, right)
+		if left.Type != right.Type {
+			return nil, newError(n.pos, "cannot compare %s with %s", left.Type, right.Type)
+		}
+		return &Operand{SQL: fmt.Sprintf("(%s %s %s)", left.SQL, n.op, right.SQL), Type: TypeBoolean}, nil
+	}
+}
+
+// compileArithmetic handles additions and subtractions of numbers, and the date arithmetic:
+// days are added to and subtracted from dates and timestamps, subtracting dates gives the days between them.
+func (c *compiler) compileArithmetic(n *binaryNode, left, right *Operand) (*Operand, error) {
+	switch {
+	case left.Type == TypeNumber && right.Type == TypeNumber:
+		return number(fmt.Sprintf("(%s %s %s)", left.SQL, n.op, right.SQL)), nil
+
+	case isTime(left.Type) && right.Type == TypeNumber:
+		return addDays(left, n.op, right), nil
+
+	case n.op == "+" && left.Type == TypeNumber && isTime(right.Type):
+		return addDays(right, n.op, left), nil
+
+	case n.op == "-" && isTime(left.Type) && isTime(right.Type):
+		if left.Type == TypeDate && right.Type == TypeDate {
+			return number(fmt.Sprintf("(%s - %s)::numeric", left.SQL, right.SQL)), nil
+		}
+		left, right = unifyTimes(left, right)
+		return number(fmt.Sprintf("(extract(epoch from (%s - %s)) / 86400)::numeric", left.SQL, right.SQL)), nil
+
+	default:
+		return nil, newError(n.pos, "%s cannot be applied to %s and %s", n.op, left.Type, right.Type)
+	}
+}
+
+func addDays(t *Operand, op string, days *Operand) *Operand {
+	sql := fmt.Sprintf("(%s %s %s * interval '1 day')", t.SQL, op, days.SQL)
+	if t.Type == TypeDate {
+		return &Operand{SQL: sql + "::date", Type: TypeDate}
+	}
+	return &Operand{SQL: sql, Type: TypeTimestamp}
+}
+
+func (c *compiler) compileCall(n *callNode) (*Operand, error) {
+	args := make([]*Operand, 0, len(n.args))
+	for _, arg := range n.args {
+		compiled, err := c.compileNode(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, compiled)
+	}
+
+	switch n.name {
+	case "IF":
+		if len(args) != 2 && len(args) != 3 {
+			return nil, newError(n.pos, "IF expects 2 or 3 arguments")
+		}
+		if args[0].Type != TypeBoolean {
+			return nil, newError(n.pos, "IF condition must be a boolean, got %s", args[0].Type)
+		}
+		if len(args) == 2 {
+			return &Operand{SQL: fmt.Sprintf("(CASE WHEN %s THEN %s END)", args[0].SQL, args[1].SQL), Type: args[1].Type}, nil
+		}
+		then, otherwise := unifyTimes(args[1], args[2])
+		if then.Type != otherwise.Type {
+			return nil, newError(n.pos, "IF branches must have the same type, got %s and %s", then.Type, otherwise.Type)
+		}
+		return &Operand{SQL: fmt.Sprintf("(CASE WHEN %s THEN %s ELSE %s END)", args[0].SQL, then.SQL, otherwise.SQL), Type: then.Type}, nil
+
+	case "AND", "OR":
+		if err := expectArgs(n, args, 1, -1, TypeBoolean); err != nil {
+			return nil, err
+		}
+		return &Operand{SQL: "(" + joinSQL(args, " "+n.name+" ") + ")", Type: TypeBoolean}, nil
+
+	case "NOT":
+		if err := expectArgs(n, args, 1, 1, TypeBoolean); err != nil {
+			return nil, err
+		}
+		return &Operand{SQL: "(NOT " + args[0].SQL + ")", Type: TypeBoolean}, nil
+
+	case "ISBLANK":
+		if len(args) != 1 {
+			return nil, newError(n.pos, "ISBLANK expects 1 argument")
+		}
+		return &Operand{SQL: "(" + args[0].SQL + " IS NULL)", Type: TypeBoolean}, nil
+
+	case "ROUND":
+		if err := expectArgs(n, args, 1, 2, TypeNumber); err != nil {
+			return nil, err
+		}
+		if len(args) == 1 {
+			return number("round(" + args[0].SQL + ")"), nil
+		}
+		return number(fmt.Sprintf("round(%s, (%s)::integer)", args[0].SQL, args[1].SQL)), nil
+
+	case "ABS":
+		if err := expectArgs(n, args, 1, 1, TypeNumber); err != nil {
+			return nil, err
+		}
+		return number("abs(" + args[0].SQL + ")"), nil
+
+	case "MIN", "MAX":
+		if len(args) == 0 {
+			return nil, newError(n.pos, "%s expects at least 1 argument", n.name)
+		}
+		args = unifyAllTimes(args)
+		if err := expectArgs(n, args, 1, -1, args[0].Type); err != nil {
+			return nil, err
+		}
+		function := "least"
+		if n.name == "MAX" {
+			function = "greatest"
+		}
+		return &Operand{SQL: function + "(" + joinSQL(args, ", ") + ")", Type: args[0].Type}, nil
+
+	case "CONCAT":
+		texts := make([]string, 0, len(args))
+		for _, arg := range args {
+			texts = append(texts, textSQL(arg))
+		}
+		return text("concat(" + strings.Join(texts, ", ") + ")"), nil
+
+	case "LEN":
+		if err := expectArgs(n, args, 1, 1, TypeText); err != nil {
+			return nil, err
+		}
+		return number("length(" + args[0].SQL + ")::numeric"), nil
+
+	case "UPPER", "LOWER":
+		if err := expectArgs(n, args, 1, 1, TypeText); err != nil {
+			return nil, err
+		}
+		return text(strings.ToLower(n.name) + "(" + args[0].SQL + ")"), nil
+
+	case "TODAY":
+		if err := expectArgs(n, args, 0, 0, ""); err != nil {
+			return nil, err
+		}
+		return &Operand{SQL: "current_date", Type: TypeDate}, nil
+
+	case "NOW":
+		if err := expectArgs(n, args, 0, 0, ""); err != nil {
+			return nil, err
+		}
+		return &Operand{SQL: "now()", Type: TypeTimestamp}, nil
+
+	case "YEAR", "MONTH", "DAY":
+		if len(args) != 1 || !isTime(args[0].Type) {
+			return nil, newError(n.pos, "%s expects a date or a timestamp", n.name)
+		}
+		value := args[0].SQL
+		if args[0].Type == TypeTimestamp {
+			value = "(" + value + " AT TIME ZONE 'UTC')"
+		}
+		return number(fmt.Sprintf("extract(%s from %s)::numeric", strings.ToLower(n.name), value)), nil
+
+	default:
+		return nil, newError(n.pos, "unknown function %s", n.name)
+	}
+}
+
+// expectArgs checks the number of arguments, max -1 means no limit, and that all of them have the type.
+func expectArgs(n *callNode, args []*Operand, min, max int, typ Type) error {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		switch {
+		case min == max:
+			return newError(n.pos, "%s expects %d arguments", n.name, min)
+		case max < 0:
+			return newError(n.pos, "%s expects at least %d arguments", n.name, min)
+		default:
+			return newError(n.pos, "%s expects %d to %d arguments", n.name, min, max)
+		}
+	}
+	for _, arg := range args {
+		if arg.Type != typ {
+			return newError(n.pos, "%s expects %s arguments, got %s", n.name, typ, arg.Type)
+		}
+	}
+	return nil
+}
+
+// unifyTimes converts a date compared or combined with a timestamp into a timestamp.
+func unifyTimes(a, b *Operand) (*Operand, *Operand) {
+	switch {
+	case a.Type == TypeDate && b.Type == TypeTimestamp:
+		return &Operand{SQL: a.SQL + "::timestamptz", Type: TypeTimestamp}, b
+	case a.Type == TypeTimestamp && b.Type == TypeDate:
+		return a, &Operand{SQL: b.SQL + "::timestamptz", Type: TypeTimestamp}
+	default:
+		return a, b
+	}
+}
+
+// unifyAllTimes converts the dates into timestamps when some of the values are timestamps.
+func unifyAllTimes(args []*Operand) []*Operand {
+	for _, arg := range args {
+		if arg.Type != TypeTimestamp {
+			continue
+		}
+		for i := range args {
+			args[i], _ = unifyTimes(args[i], arg)
+		}
+		break
+	}
+	return args
+}
+
+func isTime(t Type) bool {
+	return t == TypeDate || t == TypeTimestamp
+}
+
+func number(sql string) *Operand {
+	return &Operand{SQL: sql, Type: TypeNumber}
+}
+
+func text(sql string) *Operand {
+	return &Operand{SQL: sql, Type: TypeText}
+}
+
+func joinSQL(args []*Operand, sep string) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		parts = append(parts, arg.SQL)
+	}
+	return strings.Join(parts, sep)
+}
+
+// Quote makes a SQL text literal, standard conforming strings only need the quotes doubled.
+// Question marks are spelled as chr(63): sqrl reads any ? of the query as a placeholder, and
+// escaping them as ?? is undone when the literal ends up in an expression with arguments.
+func Quote(s string) string {
+	parts := strings.Split(s, "?")
+	for i, part := range parts {
+		parts[i] = "'" + strings.ReplaceAll(part, "'", "''") + "'"
+	}
+	if len(parts) == 1 {
+		return parts[0] + "::text"
+	}
+	return "(" + strings.Join(parts, " || chr(63) || ") + ")::text"
+}
+
+func textSQL(op *Operand) string {
+	return TextSQL(op.SQL, op.Type)
+}
+
+// TextSQL converts a value of the type into the text it is stored and returned as,
+// e.g. dates as 2006-01-02 and timestamps as RFC 3339 in UTC.
+func TextSQL(sql string, typ Type) string {
+	switch typ {
+	case TypeText:
+		return sql
+	case TypeDate:
+		return fmt.Sprintf("to_char(%s, 'YYYY-MM-DD')", sql)
+	case TypeTimestamp:
+		return fmt.Sprintf(`to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')`, sql)
+	default:
+		return sql + "::text"
+	}
+}
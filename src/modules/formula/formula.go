@@ -0,0 +1,109 @@
+package formula
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Type is the type of a formula value.
+type Type string
+
+const (
+	TypeNumber    Type = "number"
+	TypeText      Type = "text"
+	TypeBoolean   Type = "boolean"
+	TypeDate      Type = "date"
+	TypeTimestamp Type = "timestamp"
+)
+
+// Operand is a referenced column as seen by the compiler: the SQL expression of its value and its type.
+type Operand struct {
+	SQL  string
+	Type Type
+}
+
+// Resolver resolves a column reference of a formula.
+type Resolver func(ref string) (*Operand, error)
+
+// Formula is a parsed formula expression. Columns are referenced in curly braces,
+// e.g. `IF({Status} = "done", {Price} * {Qty}, 0)`.
+type Formula struct {
+	root node
+	refs []string
+}
+
+// Error is a syntax or type error of a formula.
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos+1)
+}
+
+func newError(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func Parse(expression string) (*Formula, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, newError(tok.pos, "unexpected %q", tok.text)
+	}
+
+	return &Formula{root: root, refs: p.refs}, nil
+}
+
+// References returns the column references of the formula in order of appearance.
+func (f *Formula) References() []string {
+	return f.refs
+}
+
+// Compile type checks the formula and translates it into a SQL expression.
+// Empty values and invalid operations, like division by zero, evaluate to NULL.
+func (f *Formula) Compile(resolve Resolver) (string, Type, error) {
+	c := &compiler{resolve: resolve}
+	compiled, err := c.compileNode(f.root)
+	if err != nil {
+		return "", "", err
+	}
+	return compiled.SQL, compiled.Type, nil
+}
+
+// ReplaceReferences rewrites the column references of the expression and keeps the rest as written,
+// it is used to switch between references by column names and by column IDs.
+func ReplaceReferences(expression string, replace func(ref string) (string, error)) (string, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return "", err
+	}
+
+	builder := strings.Builder{}
+	last := 0
+	for _, tok := range tokens {
+		if tok.kind != tokenReference {
+			continue
+		}
+
+		ref, err := replace(tok.text)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(expression[last:tok.pos])
+		builder.WriteString("{" + ref + "}")
+		last = tok.end
+	}
+	builder.WriteString(expression[last:])
+
+	return builder.String(), nil
+}
@@ -0,0 +1,200 @@
+package formula
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testColumns are the columns the formulas of the tests can reference.
+var testColumns = map[string]*Operand{
+	"n":  {SQL: "n", Type: TypeNumber},
+	"t":  {SQL: "t", Type: TypeText},
+	"b":  {SQL: "b", Type: TypeBoolean},
+	"d":  {SQL: "d", Type: TypeDate},
+	"ts": {SQL: "ts", Type: TypeTimestamp},
+}
+
+func resolveTestColumn(ref string) (*Operand, error) {
+	if col, ok := testColumns[ref]; ok {
+		return col, nil
+	}
+	return nil, fmt.Errorf("column %s not found", ref)
+}
+
+func compile(expression string, resolve Resolver) (string, Type, error) {
+	parsed, err := Parse(expression)
+	if err != nil {
+		return "", "", err
+	}
+	return parsed.Compile(resolve)
+}
+
+func TestCompilePrecedence(t *testing.T) {
+	tests := []struct {
+		expression string
+		sql        string
+		typ        Type
+	}{
+		{"1 + 2 * 3", "(1::numeric + (2::numeric * 3::numeric))", TypeNumber},
+		{"(1 + 2) * 3", "((1::numeric + 2::numeric) * 3::numeric)", TypeNumber},
+		{"1 - 2 - 3", "((1::numeric - 2::numeric) - 3::numeric)", TypeNumber},
+		{"8 / 4 / 2", "((8::numeric / nullif(4::numeric, 0)) / nullif(2::numeric, 0))", TypeNumber},
+		{"-1 * 2", "((-1::numeric) * 2::numeric)", TypeNumber},
+		{"--{n}", "(-(-(n)))", TypeNumber},
+		{"1 + 2 & 3", "concat((1::numeric + 2::numeric)::text, 3::numeric::text)", TypeText},
+		{"{t} & 1 = \"a1\"", "(concat((t), 1::numeric::text) = 'a1'::text)", TypeBoolean},
+		{"1 + 1 >= 2 * 1", "((1::numeric + 1::numeric) >= (2::numeric * 1::numeric))", TypeBoolean},
+		{"{n} != 1", "((n) <> 1::numeric)", TypeBoolean},
+		{"true = FALSE", "(true = false)", TypeBoolean},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			sql, typ, err := compile(tt.expression, resolveTestColumn)
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			if sql != tt.sql || typ != tt.typ {
+				t.Fatalf("got %s (%s), want %s (%s)", sql, typ, tt.sql, tt.typ)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"", "unexpected \"end of formula\" at position 1"},
+		{"1 +", "unexpected \"end of formula\" at position 4"},
+		{"(1 + 2", "expected ) instead of \"end of formula\" at position 7"},
+		{"1 2", "unexpected \"2\" at position 3"},
+		{"1 < 2 < 3", "comparisons cannot be chained at position 7"},
+		{"\"abc", "unterminated string at position 1"},
+		{"{n", "unterminated column reference at position 1"},
+		{"{}", "empty column reference at position 1"},
+		{"1 # 2", "unexpected character '#' at position 3"},
+		{"price * 2", "unknown name \"price\", columns are referenced as {price} at position 1"},
+		{"ROUND(1 2)", "expected , or ) instead of \"2\" at position 9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			if err == nil {
+				t.Fatal("Parse succeeded")
+			}
+			if err.Error() != tt.err {
+				t.Fatalf("got error %q, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCompileTypes(t *testing.T) {
+	tests := []struct {
+		expression string
+		typ        Type
+		err        string
+	}{
+		{expression: "{n} * 2", typ: TypeNumber},
+		{expression: "{t} & {n}", typ: TypeText},
+		{expression: "{d} + 1", typ: TypeDate},
+		{expression: "1 + {ts}", typ: TypeTimestamp},
+		{expression: "{d} - {d}", typ: TypeNumber},
+		{expression: "{d} < {ts}", typ: TypeBoolean},
+		{expression: "IF({b}, 1, 2)", typ: TypeNumber},
+		{expression: "LEN({t})", typ: TypeNumber},
+		{expression: "{t} * 2", err: "* expects numbers, got text and number at position 5"},
+		{expression: "-{t}", err: "- expects a number, got text at position 1"},
+		{expression: "{t} + 1", err: "+ cannot be applied to text and number at position 5"},
+		{expression: "{n} = {t}", err: "cannot compare number with text at position 5"},
+		{expression: "IF({n}, 1, 2)", err: "IF condition must be a boolean, got number at position 1"},
+		{expression: "FOO(1)", err: "unknown function FOO at position 1"},
+		{expression: "{missing} + 1", err: "column missing not found at position 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, typ, err := compile(tt.expression, resolveTestColumn)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			if typ != tt.typ {
+				t.Fatalf("got type %s, want %s", typ, tt.typ)
+			}
+		})
+	}
+}
+
+func TestCompileReportsReferencedErrorsAtReference(t *testing.T) {
+	resolve := func(ref string) (*Operand, error) {
+		return nil, newError(7, "%s is broken", ref)
+	}
+
+	_, _, err := compile("1 + {other}", resolve)
+	if err == nil || err.Error() != "other is broken at position 5" {
+		t.Fatalf("got error %v, want %q", err, "other is broken at position 5")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		expression string
+		sql        string
+	}{
+		{`"plain"`, `'plain'::text`},
+		{`"it's"`, `'it''s'::text`},
+		{`"''"`, `''''''::text`},
+		{`"say ""hi"""`, `'say "hi"'::text`},
+		{`"C:\temp\"`, `'C:\temp\'::text`},
+		{`"\' OR 1=1 --"`, `'\'' OR 1=1 --'::text`},
+		{`"?"`, `('' || chr(63) || '')::text`},
+		{`"it's ??"`, `('it''s ' || chr(63) || '' || chr(63) || '')::text`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			sql, typ, err := compile(tt.expression, resolveTestColumn)
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			if sql != tt.sql || typ != TypeText {
+				t.Fatalf("got %s (%s), want %s (text)", sql, typ, tt.sql)
+			}
+		})
+	}
+}
+
+func TestTextSQL(t *testing.T) {
+	tests := []struct {
+		expression string
+		sql        string
+	}{
+		{`"it's \"`, `'it''s \'::text`},
+		{`{t}`, `(t)`},
+		{`{n}`, `(n)::text`},
+		{`{b}`, `(b)::text`},
+		{`{d}`, `to_char((d), 'YYYY-MM-DD')`},
+		{`{ts}`, `to_char((ts) AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			sql, typ, err := compile(tt.expression, resolveTestColumn)
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			if got := TextSQL(sql, typ); got != tt.sql {
+				t.Fatalf("got %s, want %s", got, tt.sql)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package formula
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenReference
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	// text is the token without quotes and braces for strings and references
+	text string
+	pos  int
+	end  int
+}
+
+var operators = []string{"<=", ">=", "<>", "!=", "+", "-", "*", "/", "&", "=", "<", ">"}
+
+func tokenize(expression string) ([]token, error) {
+	tokens := make([]token, 0)
+	runes := []rune(expression)
+	// positions are byte offsets, so the tokens can be cut out of the expression
+	offsets := make([]int, len(runes)+1)
+	for i, offset := 0, 0; i < len(runes); i++ {
+		offsets[i] = offset
+		offset += len(string(runes[i]))
+		offsets[i+1] = offset
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+
+		case r == '{':
+			i++
+			for i < len(runes) && runes[i] != '}' {
+				i++
+			}
+			if i == len(runes) {
+				return nil, newError(offsets[start], "unterminated column reference")
+			}
+			ref := strings.TrimSpace(string(runes[start+1 : i]))
+			if ref == "" {
+				return nil, newError(offsets[start], "empty column reference")
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenReference, text: ref, pos: offsets[start], end: offsets[i]})
+
+		case r == '"':
+			i++
+			text := strings.Builder{}
+			for {
+				if i == len(runes) {
+					return nil, newError(offsets[start], "unterminated string")
+				}
+				if runes[i] == '"' {
+					// a doubled quote stands for a quote
+					if i+1 < len(runes) && runes[i+1] == '"' {
+						text.WriteRune('"')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				text.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text.String(), pos: offsets[start], end: offsets[i]})
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			seenDot := false
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || (runes[i] == '.' && !seenDot)) {
+				if runes[i] == '.' {
+					seenDot = true
+				}
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: offsets[start], end: offsets[i]})
+
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: offsets[start], end: offsets[i]})
+
+		case r == '(':
+			i++
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: offsets[start], end: offsets[i]})
+		case r == ')':
+			i++
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: offsets[start], end: offsets[i]})
+		case r == ',':
+			i++
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: offsets[start], end: offsets[i]})
+
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(string(runes[i:]), candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, newError(offsets[start], "unexpected character %q", r)
+			}
+			i += len([]rune(op))
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: offsets[start], end: offsets[i]})
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, text: "end of formula", pos: len(expression), end: len(expression)})
+	return tokens, nil
+}
@@ -0,0 +1,215 @@
+package formula
+
+import "strings"
+
+type node interface {
+	position() int
+}
+
+type numberNode struct {
+	pos   int
+	value string
+}
+
+type stringNode struct {
+	pos   int
+	value string
+}
+
+type booleanNode struct {
+	pos   int
+	value bool
+}
+
+type referenceNode struct {
+	pos int
+	ref string
+}
+
+type unaryNode struct {
+	pos     int
+	op      string
+	operand node
+}
+
+type binaryNode struct {
+	pos         int
+	op          string
+	left, right node
+}
+
+type callNode struct {
+	pos  int
+	name string
+	args []node
+}
+
+func (n *numberNode) position() int    { return n.pos }
+func (n *stringNode) position() int    { return n.pos }
+func (n *booleanNode) position() int   { return n.pos }
+func (n *referenceNode) position() int { return n.pos }
+func (n *unaryNode) position() int     { return n.pos }
+func (n *binaryNode) position() int    { return n.pos }
+func (n *callNode) position() int      { return n.pos }
+
+// parser is a recursive descent parser, from the lowest precedence:
+// comparisons, concatenation, addition and subtraction, multiplication and division, unary minus.
+type parser struct {
+	tokens []token
+	cur    int
+	refs   []string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.cur]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.cur]
+	if tok.kind != tokenEOF {
+		p.cur++
+	}
+	return tok
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	tok := p.peek()
+	if tok.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseExpression() (node, error) {
+	left, err := p.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.isOperator("=", "<>", "!=", "<", "<=", ">", ">=") {
+		op := p.next()
+		right, err := p.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+		if p.isOperator("=", "<>", "!=", "<", "<=", ">", ">=") {
+			return nil, newError(p.peek().pos, "comparisons cannot be chained")
+		}
+		text := op.text
+		if text == "!=" {
+			text = "<>"
+		}
+		return &binaryNode{pos: op.pos, op: text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseBinary(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator(ops...) {
+		op := p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: op.pos, op: op.text, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseConcat() (node, error) {
+	return p.parseBinary(p.parseAdditive, "&")
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	return p.parseBinary(p.parseUnary, "*", "/")
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("-") {
+		op := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{pos: op.pos, op: op.text, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		return &numberNode{pos: tok.pos, value: tok.text}, nil
+
+	case tokenString:
+		return &stringNode{pos: tok.pos, value: tok.text}, nil
+
+	case tokenReference:
+		p.refs = append(p.refs, tok.text)
+		return &referenceNode{pos: tok.pos, ref: tok.text}, nil
+
+	case tokenLParen:
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, newError(closing.pos, "expected ) instead of %q", closing.text)
+		}
+		return expr, nil
+
+	case tokenIdent:
+		name := strings.ToUpper(tok.text)
+		if p.peek().kind != tokenLParen {
+			switch name {
+			case "TRUE":
+				return &booleanNode{pos: tok.pos, value: true}, nil
+			case "FALSE":
+				return &booleanNode{pos: tok.pos, value: false}, nil
+			default:
+				return nil, newError(tok.pos, "unknown name %q, columns are referenced as {%s}", tok.text, tok.text)
+			}
+		}
+		p.next()
+
+		args := make([]node, 0)
+		if p.peek().kind == tokenRParen {
+			p.next()
+			return &callNode{pos: tok.pos, name: name, args: args}, nil
+		}
+		for {
+			arg, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			sep := p.next()
+			if sep.kind == tokenRParen {
+				break
+			}
+			if sep.kind != tokenComma {
+				return nil, newError(sep.pos, "expected , or ) instead of %q", sep.text)
+			}
+		}
+		return &callNode{pos: tok.pos, name: name, args: args}, nil
+
+	default:
+		return nil, newError(tok.pos, "unexpected %q", tok.text)
+	}
+}
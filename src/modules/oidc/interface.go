@@ -0,0 +1,43 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+)
+
+type IProvider interface {
+	// Issuer returns the issuer identifier that ID tokens are checked against.
+	Issuer() string
+	// AuthCodeURL builds the authorization endpoint URL for the code flow with PKCE (S256).
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	// Exchange redeems the authorization code and returns the validated ID token claims.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error)
+}
+
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type ErrorInvalidIDToken struct {
+	Reason string
+}
+
+func (e ErrorInvalidIDToken) Error() string {
+	return "Invalid ID token: " + e.Reason
+}
+
+func IsErrInvalidIDToken(err error) bool {
+	target := ErrorInvalidIDToken{}
+	return errors.As(err, &target)
+}
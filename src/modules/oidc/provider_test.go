@@ -0,0 +1,275 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID    = "simple-table"
+	testRedirectURL = "http://localhost:3000/oidc/callback"
+	testKeyID       = "test-key"
+)
+
+// mockIdP is a minimal OpenID provider serving discovery, JWKS, the authorization
+// endpoint and the token endpoint checking the PKCE verifier.
+type mockIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+	// tokenNonce overrides the nonce put into the ID tokens when set.
+	tokenNonce string
+	// discoveryIssuer overrides the issuer announced by discovery when set.
+	discoveryIssuer string
+}
+
+type authorization struct {
+	challenge string
+	nonce     string
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	idp := &mockIdP{key: key, codes: make(map[string]authorization)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (m *mockIdP) issuer() string {
+	return m.server.URL
+}
+
+func (m *mockIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	issuer := m.issuer()
+	if m.discoveryIssuer != "" {
+		issuer = m.discoveryIssuer
+	}
+	_ = json.NewEncoder(w).Encode(discoveryDocument{
+		Issuer:                issuer,
+		AuthorizationEndpoint: m.issuer() + "/authorize",
+		TokenEndpoint:         m.issuer() + "/token",
+		JWKSURI:               m.issuer() + "/jwks",
+	})
+}
+
+func (m *mockIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+		Kid: testKeyID,
+		Kty: "RSA",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+	}}})
+}
+
+func (m *mockIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("client_id") != testClientID {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	code := "code-" + query.Get("state")
+	m.mu.Lock()
+	m.codes[code] = authorization{challenge: query.Get("code_challenge"), nonce: query.Get("nonce")}
+	m.mu.Unlock()
+
+	redirect, _ := url.Parse(query.Get("redirect_uri"))
+	redirect.RawQuery = url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (m *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	auth, ok := m.codes[r.PostForm.Get("code")]
+	delete(m.codes, r.PostForm.Get("code"))
+	m.mu.Unlock()
+	if !ok || CodeChallenge(r.PostForm.Get("code_verifier")) != auth.challenge {
+		http.Error(w, "invalid_grant", http.StatusBadRequest)
+		return
+	}
+
+	nonce := auth.nonce
+	if m.tokenNonce != "" {
+		nonce = m.tokenNonce
+	}
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            m.issuer(),
+		"aud":            testClientID,
+		"sub":            "user-1",
+		"email":          "user@example.com",
+		"email_verified": "true",
+		"name":           "Test User",
+		"nonce":          nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = testKeyID
+	idToken, err := token.SignedString(m.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+}
+
+// authorizeCode walks the authorization step as the browser would and returns the issued code.
+func authorizeCode(t *testing.T, p IProvider, state, nonce, verifier string) string {
+	t.Helper()
+
+	authURL, err := p.AuthCodeURL(context.Background(), state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: unexpected status %d", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	if got := location.Query().Get("state"); got != state {
+		t.Fatalf("authorize: state %q, want %q", got, state)
+	}
+	return location.Query().Get("code")
+}
+
+func newTestProvider(idp *mockIdP) IProvider {
+	return NewProvider(Config{
+		IssuerURL:   idp.issuer() + "/",
+		ClientID:    testClientID,
+		RedirectURL: testRedirectURL,
+	})
+}
+
+func TestProviderCodeFlow(t *testing.T) {
+	idp := newMockIdP(t)
+	p := newTestProvider(idp)
+
+	code := authorizeCode(t, p, "state-1", "nonce-1", "verifier-1")
+	claims, err := p.Exchange(context.Background(), code, "verifier-1", "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	want := Claims{Subject: "user-1", Email: "user@example.com", EmailVerified: true, Name: "Test User"}
+	if *claims != want {
+		t.Fatalf("claims %+v, want %+v", *claims, want)
+	}
+}
+
+func TestProviderExchangeFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		verifier   string
+		nonce      string
+		tokenNonce string
+		invalidID  bool
+	}{
+		{name: "wrong PKCE verifier", verifier: "other-verifier", nonce: "nonce-1"},
+		{name: "nonce of another login", verifier: "verifier-1", nonce: "nonce-2", invalidID: true},
+		{name: "nonce replaced by the IdP", verifier: "verifier-1", nonce: "nonce-1", tokenNonce: "forged", invalidID: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newMockIdP(t)
+			idp.tokenNonce = tt.tokenNonce
+			p := newTestProvider(idp)
+
+			code := authorizeCode(t, p, "state-1", "nonce-1", "verifier-1")
+			_, err := p.Exchange(context.Background(), code, tt.verifier, tt.nonce)
+			if err == nil {
+				t.Fatal("Exchange succeeded")
+			}
+			if IsErrInvalidIDToken(err) != tt.invalidID {
+				t.Fatalf("IsErrInvalidIDToken(%v) = %v, want %v", err, !tt.invalidID, tt.invalidID)
+			}
+		})
+	}
+}
+
+func TestProviderDiscoveryIssuerMismatch(t *testing.T) {
+	idp := newMockIdP(t)
+	idp.discoveryIssuer = "https://evil.example.com"
+	p := newTestProvider(idp)
+
+	if _, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier-1"); err == nil {
+		t.Fatal("AuthCodeURL succeeded with a mismatching issuer")
+	}
+}
+
+func TestAuthCodeURLParameters(t *testing.T) {
+	idp := newMockIdP(t)
+	p := newTestProvider(idp)
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", authURL, err)
+	}
+
+	query := parsed.Query()
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          testRedirectURL,
+		"scope":                 "openid email profile",
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        CodeChallenge("verifier-1"),
+		"code_challenge_method": "S256",
+	}
+	for param, value := range want {
+		if got := query.Get(param); got != value {
+			t.Errorf("%s = %q, want %q", param, got, value)
+		}
+	}
+}
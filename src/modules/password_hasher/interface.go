@@ -0,0 +1,26 @@
+package password_hasher
+
+import (
+	"errors"
+	"fmt"
+)
+
+type IPasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash string, password string) (bool, error)
+	NeedsRehash(hash string) bool
+}
+
+// ErrorPasswordTooLong is returned by Hash for passwords longer than the hash can take into account.
+type ErrorPasswordTooLong struct {
+	MaxBytes int
+}
+
+func (e ErrorPasswordTooLong) Error() string {
+	return fmt.Sprintf("Password must not be longer than %d bytes", e.MaxBytes)
+}
+
+func IsErrPasswordTooLong(err error) bool {
+	target := ErrorPasswordTooLong{}
+	return errors.As(err, &target)
+}
@@ -0,0 +1,34 @@
+package rate_limiter
+
+import (
+	"context"
+	"time"
+)
+
+type IRateLimiter interface {
+	// Allow registers a hit for the key and reports whether it still fits into the limit.
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+	// Check reports whether the key is within the limit without registering a hit.
+	Check(ctx context.Context, key string, limit Limit) (Result, error)
+	Reset(ctx context.Context, key string) error
+}
+
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+type Result struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+func newResult(hits int, windowStartedAt time.Time, limit Limit) Result {
+	if hits <= limit.Requests {
+		return Result{Allowed: true}
+	}
+	return Result{
+		Allowed:    false,
+		RetryAfter: time.Until(windowStartedAt.Add(limit.Window)),
+	}
+}
@@ -0,0 +1,83 @@
+package rate_limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memoryCleanupInterval = time.Minute
+
+type memoryWindow struct {
+	hits      int
+	startedAt time.Time
+	expiresAt time.Time
+}
+
+type memoryRateLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*memoryWindow
+}
+
+func NewMemoryRateLimiter(ctx context.Context) IRateLimiter {
+	l := &memoryRateLimiter{
+		windows: make(map[string]*memoryWindow),
+	}
+	go l.cleanup(ctx)
+	return l
+}
+
+func (l *memoryRateLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	w, ok := l.windows[key]
+	if !ok || now.After(w.expiresAt) {
+		w = &memoryWindow{startedAt: now, expiresAt: now.Add(limit.Window)}
+		l.windows[key] = w
+	}
+	w.hits++
+
+	return newResult(w.hits, w.startedAt, limit), nil
+}
+
+func (l *memoryRateLimiter) Check(ctx context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.windows[key]
+	if !ok || time.Now().After(w.expiresAt) {
+		return Result{Allowed: true}, nil
+	}
+
+	return newResult(w.hits+1, w.startedAt, limit), nil
+}
+
+func (l *memoryRateLimiter) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.windows, key)
+	return nil
+}
+
+func (l *memoryRateLimiter) cleanup(ctx context.Context) {
+	ticker := time.NewTicker(memoryCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			l.mu.Lock()
+			for key, w := range l.windows {
+				if now.After(w.expiresAt) {
+					delete(l.windows, key)
+				}
+			}
+			l.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package rate_limiter
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KeyBy string
+
+const (
+	KeyByIP   KeyBy = "ip"
+	KeyByUser KeyBy = "user"
+)
+
+type Rule struct {
+	Name  string
+	KeyBy KeyBy
+	Limit Limit
+}
+
+func (r Rule) key(c *gin.Context) (string, bool) {
+	switch r.KeyBy {
+	case KeyByUser:
+		userID, ok := c.Get("user_id")
+		if !ok {
+			return "", false
+		}
+		return r.Name + ":user:" + strconv.FormatInt(userID.(int64), 10), true
+	default:
+		return r.Name + ":ip:" + c.ClientIP(), true
+	}
+}
+
+func Middleware(limiter IRateLimiter, rules []Rule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, rule := range rules {
+			key, ok := rule.key(c)
+			if !ok {
+				continue
+			}
+
+			res, err := limiter.Allow(c, key, rule.Limit)
+			if err != nil {
+				// Better to let the request through than to lock everybody out because of the storage.
+				log.Printf("Error checking rate limit %s: %v", key, err)
+				continue
+			}
+			if !res.Allowed {
+				AbortWithTooManyRequests(c, res)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func AbortWithTooManyRequests(c *gin.Context, res Result) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds()))))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests"})
+}
@@ -0,0 +1,100 @@
+package rate_limiter
+
+import (
+	"backend/src/modules/sql_executor"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/elgris/sqrl"
+)
+
+const (
+	rateLimitsTable          = "app.rate_limits"
+	postgresCleanupInterval  = time.Hour
+	postgresStaleWindowAfter = 24 * time.Hour
+)
+
+type postgresWindow struct {
+	Hits            int       `db:"hits"`
+	WindowStartedAt time.Time `db:"window_started_at"`
+}
+
+type postgresRateLimiter struct {
+	executor sql_executor.ISQLExecutor
+}
+
+// NewPostgresRateLimiter keeps counters in Postgres, so that limits are shared by all instances.
+func NewPostgresRateLimiter(ctx context.Context, executor sql_executor.ISQLExecutor) IRateLimiter {
+	l := &postgresRateLimiter{
+		executor: executor,
+	}
+	go l.cleanup(ctx)
+	return l
+}
+
+func (l *postgresRateLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	windowSeconds := limit.Window.Seconds()
+	q := sqrl.Insert(rateLimitsTable).
+		Columns("key", "hits", "window_started_at").
+		Values(key, 1, sqrl.Expr("now()")).
+		Suffix(`ON CONFLICT (key) DO UPDATE SET
+hits = CASE WHEN rate_limits.window_started_at <= now() - make_interval(secs => ?) THEN 1 ELSE rate_limits.hits + 1 END,
+window_started_at = CASE WHEN rate_limits.window_started_at <= now() - make_interval(secs => ?) THEN now() ELSE rate_limits.window_started_at END
+RETURNING hits, window_started_at`, windowSeconds, windowSeconds).
+		PlaceholderFormat(sqrl.Dollar)
+
+	w := &postgresWindow{}
+	if err := l.executor.Run(ctx, w, q); err != nil {
+		return Result{}, err
+	}
+
+	return newResult(w.Hits, w.WindowStartedAt, limit), nil
+}
+
+func (l *postgresRateLimiter) Check(ctx context.Context, key string, limit Limit) (Result, error) {
+	q := sqrl.Select("hits", "window_started_at").
+		From(rateLimitsTable).
+		Where(sqrl.Eq{"key": key}).
+		Where(sqrl.Expr("window_started_at > now() - make_interval(secs => ?)", limit.Window.Seconds())).
+		PlaceholderFormat(sqrl.Dollar)
+
+	w := &postgresWindow{}
+	if err := l.executor.Run(ctx, w, q); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Result{Allowed: true}, nil
+		}
+		return Result{}, err
+	}
+
+	return newResult(w.Hits+1, w.WindowStartedAt, limit), nil
+}
+
+func (l *postgresRateLimiter) Reset(ctx context.Context, key string) error {
+	q := sqrl.Delete(rateLimitsTable).
+		Where(sqrl.Eq{"key": key}).
+		PlaceholderFormat(sqrl.Dollar)
+
+	_, err := l.executor.Exec(ctx, q)
+	return err
+}
+
+func (l *postgresRateLimiter) cleanup(ctx context.Context) {
+	ticker := time.NewTicker(postgresCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q := sqrl.Delete(rateLimitsTable).
+				Where(sqrl.Lt{"window_started_at": time.Now().Add(-postgresStaleWindowAfter)}).
+				PlaceholderFormat(sqrl.Dollar)
+			if _, err := l.executor.Exec(ctx, q); err != nil {
+				log.Printf("Error cleaning up rate limits: %v", err)
+			}
+		}
+	}
+}
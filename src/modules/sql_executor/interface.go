@@ -8,6 +8,9 @@ import (
 type ISQLExecutor interface {
 	Run(ctx context.Context, dest interface{}, query IToSQL) error
 	Exec(ctx context.Context, query IToSQL) (sql.Result, error)
+	// InTransaction runs fn in a transaction carried by the context passed to it, so every
+	// Run and Exec made with that context joins the transaction. Nested calls reuse it.
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type IToSQL interface {
@@ -12,6 +12,14 @@ import (
 	_ "github.com/lib/pq"
 )
 
+type txContextKey struct{}
+
+// queryer is implemented by both *sqlx.DB and *sqlx.Tx.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error)
+}
+
 type sqlxExecutor struct {
 	db      *sqlx.DB
 	scanner *dbscan.API
@@ -44,7 +52,41 @@ func (e *sqlxExecutor) Exec(ctx context.Context, query IToSQL) (sql.Result, erro
 		return nil, err
 	}
 
-	return e.db.Exec(queryString, args...)
+	return e.queryer(ctx).ExecContext(ctx, queryString, args...)
+}
+
+func (e *sqlxExecutor) InTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txContextKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := e.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Printf("Error rolling back transaction: %v", rollbackErr)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	return fn(context.WithValue(ctx, txContextKey{}, tx))
+}
+
+func (e *sqlxExecutor) queryer(ctx context.Context) queryer {
+	if tx, ok := ctx.Value(txContextKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return e.db
 }
 
 func (e *sqlxExecutor) Run(ctx context.Context, dest interface{}, query IToSQL) error {
@@ -63,7 +105,7 @@ func (e *sqlxExecutor) selectByQuery(ctx context.Context, dest interface{}, quer
 		return errors.New("dest must be a pointer to a struct")
 	}
 
-	rows, err := e.db.QueryxContext(ctx, query, args...)
+	rows, err := e.queryer(ctx).QueryxContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -15,18 +15,30 @@ type Message struct {
 	EventAction string      `json:"eventAction"`
 	Payload     interface{} `json:"payload"`
 	EventTime   time.Time   `json:"eventTime"`
+
+	// recipients limits the message to these users, everyone subscribed gets it when nil
+	recipients map[int64]struct{}
 }
 
 type client struct {
-	conn *websocket.Conn
-	send chan []byte
+	conn   *websocket.Conn
+	send   chan []byte
+	userID int64
 }
 
+// Authorizer decides whether the user may subscribe to the topic.
+type Authorizer func(ctx context.Context, userID int64, topic string) (bool, error)
+
+// Relay is called for every message broadcast by the hub.
+type Relay func(topic, eventAction string)
+
 type Hub struct {
 	mu          sync.RWMutex
+	relays      []Relay
 	subscribers map[string]map[*client]struct{}
 	register    chan registration
 	unregister  chan registration
+	disconnect  chan disconnection
 	broadcast   chan Message
 	ctx         context.Context
 	cancel      context.CancelFunc
@@ -37,12 +49,18 @@ type registration struct {
 	client *client
 }
 
+type disconnection struct {
+	topics []string
+	userID int64
+}
+
 func NewHub(ctx context.Context) *Hub {
 	_ctx, cancel := context.WithCancel(ctx)
 	h := &Hub{
 		subscribers: make(map[string]map[*client]struct{}),
 		register:    make(chan registration),
 		unregister:  make(chan registration),
+		disconnect:  make(chan disconnection, 64),
 		broadcast:   make(chan Message, 1024),
 		ctx:         _ctx,
 		cancel:      cancel,
@@ -76,7 +94,27 @@ func (h *Hub) run() {
 				}
 			}
 			h.mu.Unlock()
+		case d := <-h.disconnect:
+			h.mu.Lock()
+			for _, topic := range d.topics {
+				set, ok := h.subscribers[topic]
+				if !ok {
+					continue
+				}
+				for c := range set {
+					if c.userID != d.userID {
+						continue
+					}
+					delete(set, c)
+					close(c.send)
+				}
+				if len(set) == 0 {
+					delete(h.subscribers, topic)
+				}
+			}
+			h.mu.Unlock()
 		case msg := <-h.broadcast:
+			h.relay(msg)
 			b, err := json.Marshal(msg)
 			if err != nil {
 				log.Printf("ws marshal error: %v", err)
@@ -85,6 +123,11 @@ func (h *Hub) run() {
 			h.mu.RLock()
 			set := h.subscribers[msg.Topic]
 			for c := range set {
+				if msg.recipients != nil {
+					if _, ok := msg.recipients[c.userID]; !ok {
+						continue
+					}
+				}
 				select {
 				case c.send <- b:
 				default:
@@ -108,17 +151,69 @@ func (h *Hub) closeAll() {
 	}
 }
 
+// OnBroadcast registers a relay. Relays run in the hub loop and must not block.
+func (h *Hub) OnBroadcast(relay Relay) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.relays = append(h.relays, relay)
+}
+
+func (h *Hub) relay(msg Message) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, relay := range h.relays {
+		relay(msg.Topic, msg.EventAction)
+	}
+}
+
 func (h *Hub) Broadcast(topic, eventAction string, payload interface{}) {
-	select {
-	case h.broadcast <- Message{
+	h.send(Message{
 		Topic:       topic,
 		EventAction: eventAction,
 		Payload:     payload,
 		EventTime:   time.Now().UTC(),
-	}:
+	})
+}
+
+// BroadcastTo sends the message only to the given users among the topic subscribers.
+func (h *Hub) BroadcastTo(topic string, userIDs []int64, eventAction string, payload interface{}) {
+	recipients := make(map[int64]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		recipients[id] = struct{}{}
+	}
+
+	h.send(Message{
+		Topic:       topic,
+		EventAction: eventAction,
+		Payload:     payload,
+		EventTime:   time.Now().UTC(),
+		recipients:  recipients,
+	})
+}
+
+func (h *Hub) send(msg Message) {
+	select {
+	case h.broadcast <- msg:
 	default:
-		log.Printf("ws broadcast queue full (topic=%s, eventAction=%s)", topic, eventAction)
+		log.Printf("ws broadcast queue full (topic=%s, eventAction=%s)", msg.Topic, msg.EventAction)
+	}
+}
+
+// Subscribers returns the distinct users subscribed to the topic.
+func (h *Hub) Subscribers(topic string) []int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	seen := make(map[int64]struct{}, len(h.subscribers[topic]))
+	userIDs := make([]int64, 0, len(h.subscribers[topic]))
+	for c := range h.subscribers[topic] {
+		if _, ok := seen[c.userID]; ok {
+			continue
+		}
+		seen[c.userID] = struct{}{}
+		userIDs = append(userIDs, c.userID)
 	}
+	return userIDs
 }
 
 func (h *Hub) BroadcastMany(topics []string, eventAction string, payload interface{}) {
@@ -127,4 +222,12 @@ func (h *Hub) BroadcastMany(topics []string, eventAction string, payload interfa
 	}
 }
 
+// Disconnect closes every connection of the user subscribed to one of the topics.
+func (h *Hub) Disconnect(topics []string, userID int64) {
+	select {
+	case h.disconnect <- disconnection{topics: topics, userID: userID}:
+	case <-h.ctx.Done():
+	}
+}
+
 func (h *Hub) Shutdown() { h.cancel() }
@@ -2,6 +2,8 @@ package web_sockets
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,13 +17,20 @@ var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool { return true },
 }
 
+// Browsers cannot set the Authorization header on handshakes, and the query ends up in the access logs,
+// so they offer AuthProtocol and send the access token as the AuthTokenProtocolPrefix subprotocol.
+const (
+	AuthProtocol            = "access-token"
+	AuthTokenProtocolPrefix = "access-token."
+)
+
 const (
 	writeWait  = 10 * time.Second
 	pongWait   = 60 * time.Second
 	pingPeriod = 50 * time.Second
 )
 
-func ServeWS(h *Hub) gin.HandlerFunc {
+func ServeWS(h *Hub, authorize Authorizer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		topic := c.DefaultQuery("topic", "")
 		if topic == "" {
@@ -29,22 +38,58 @@ func ServeWS(h *Hub) gin.HandlerFunc {
 			return
 		}
 
-		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+		userID := c.MustGet("user_id").(int64)
+		authorized, err := authorize(c, userID, topic)
 		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-
-		cl := &client{
-			conn: conn,
-			send: make(chan []byte, 256),
+		if !authorized {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "topic is not available"})
+			return
 		}
 
-		h.register <- registration{topic: topic, client: cl}
+		Serve(h, c, topic, userID, AuthProtocol)
+	}
+}
 
-		go readPump(h, topic, cl)
+// AuthToken returns the access token sent as a subprotocol of the handshake, or an empty string.
+func AuthToken(r *http.Request) string {
+	protocols := websocket.Subprotocols(r)
+	if !slices.Contains(protocols, AuthProtocol) {
+		return ""
+	}
+	for _, protocol := range protocols {
+		if token, ok := strings.CutPrefix(protocol, AuthTokenProtocolPrefix); ok {
+			return token
+		}
+	}
+	return ""
+}
+
+// Serve upgrades the already authorized request and subscribes the connection to the topic.
+// The handshake is answered with the protocol when the client offered it.
+func Serve(h *Hub, c *gin.Context, topic string, userID int64, protocol string) {
+	header := http.Header{}
+	if slices.Contains(websocket.Subprotocols(c.Request), protocol) {
+		header.Set("Sec-WebSocket-Protocol", protocol)
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, header)
+	if err != nil {
+		return
+	}
 
-		go writePump(topic, cl, h)
+	cl := &client{
+		conn:   conn,
+		send:   make(chan []byte, 256),
+		userID: userID,
 	}
+
+	h.register <- registration{topic: topic, client: cl}
+
+	go readPump(h, topic, cl)
+
+	go writePump(topic, cl, h)
 }
 
 func readPump(h *Hub, topic string, c *client) {
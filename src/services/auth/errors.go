@@ -1,7 +1,81 @@
 package auth
 
+import (
+	"errors"
+	"time"
+)
+
 type ErrorWrongPassword struct{}
 
 func (e ErrorWrongPassword) Error() string {
 	return "Wrong password"
 }
+
+type ErrorInvalidRefreshToken struct{}
+
+func (e ErrorInvalidRefreshToken) Error() string {
+	return "Invalid refresh token"
+}
+
+func IsErrInvalidRefreshToken(err error) bool {
+	target := ErrorInvalidRefreshToken{}
+	return errors.As(err, &target)
+}
+
+type ErrorSessionNotFound struct{}
+
+func (e ErrorSessionNotFound) Error() string {
+	return "Session not found"
+}
+
+func IsErrSessionNotFound(err error) bool {
+	target := ErrorSessionNotFound{}
+	return errors.As(err, &target)
+}
+
+type ErrorPersonalAccessTokenNotFound struct{}
+
+func (e ErrorPersonalAccessTokenNotFound) Error() string {
+	return "Personal access token not found"
+}
+
+func IsErrPersonalAccessTokenNotFound(err error) bool {
+	target := ErrorPersonalAccessTokenNotFound{}
+	return errors.As(err, &target)
+}
+
+type ErrorTooManyLoginAttempts struct {
+	RetryAfter time.Duration
+}
+
+func (e ErrorTooManyLoginAttempts) Error() string {
+	return "Too many failed login attempts"
+}
+
+func IsErrTooManyLoginAttempts(err error) (ErrorTooManyLoginAttempts, bool) {
+	target := ErrorTooManyLoginAttempts{}
+	ok := errors.As(err, &target)
+	return target, ok
+}
+
+type ErrorOIDCDisabled struct{}
+
+func (e ErrorOIDCDisabled) Error() string {
+	return "Single sign-on is not configured"
+}
+
+func IsErrOIDCDisabled(err error) bool {
+	target := ErrorOIDCDisabled{}
+	return errors.As(err, &target)
+}
+
+type ErrorInvalidOIDCState struct{}
+
+func (e ErrorInvalidOIDCState) Error() string {
+	return "Invalid or expired sign-in state"
+}
+
+func IsErrInvalidOIDCState(err error) bool {
+	target := ErrorInvalidOIDCState{}
+	return errors.As(err, &target)
+}
@@ -2,78 +2,434 @@ package auth
 
 import (
 	"backend/src/domains/entities"
+	"backend/src/domains/repositories"
+	"backend/src/modules/oidc"
+	"backend/src/modules/rate_limiter"
+	"backend/src/modules/secure_token"
+	"backend/src/modules/web_sockets"
 	"backend/src/services"
+	"backend/src/services/users"
 	"context"
+	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 )
 
-const tokenExpirationTime = 100 * 24 * time.Hour
+const (
+	accessTokenExpirationTime  = 15 * time.Minute
+	refreshTokenExpirationTime = 30 * 24 * time.Hour
+
+	personalAccessTokenPrefix        = "stpat_"
+	personalAccessTokenDisplayLength = len(personalAccessTokenPrefix) + 6
+	personalAccessTokenTouchInterval = time.Minute
+
+	loginLockoutKeyPrefix = "login-failures:"
+
+	oidcLoginRequestExpirationTime = 10 * time.Minute
+)
+
+var loginFailuresLimit = rate_limiter.Limit{Requests: 5, Window: 15 * time.Minute}
 
 type service struct {
-	jwtKey       []byte
-	usersService services.IUsersService
+	jwtKey                   []byte
+	usersService             services.IUsersService
+	sessionsRepo             repositories.ISessionsRepository
+	personalAccessTokensRepo repositories.IPersonalAccessTokensRepository
+	rateLimiter              rate_limiter.IRateLimiter
+	oidcProvider             oidc.IProvider
+	oidcLoginRequestsRepo    repositories.IOIDCLoginRequestsRepository
 }
 
-func NewService(usersService services.IUsersService) services.IAuthService {
+func NewService(
+	usersService services.IUsersService,
+	sessionsRepo repositories.ISessionsRepository,
+	personalAccessTokensRepo repositories.IPersonalAccessTokensRepository,
+	rateLimiter rate_limiter.IRateLimiter,
+	oidcProvider oidc.IProvider,
+	oidcLoginRequestsRepo repositories.IOIDCLoginRequestsRepository,
+) services.IAuthService {
 	jwtKeyString := os.Getenv("JWT_KEY")
 	return &service{
-		usersService: usersService,
-		jwtKey:       []byte(jwtKeyString),
+		usersService:             usersService,
+		sessionsRepo:             sessionsRepo,
+		personalAccessTokensRepo: personalAccessTokensRepo,
+		rateLimiter:              rateLimiter,
+		oidcProvider:             oidcProvider,
+		oidcLoginRequestsRepo:    oidcLoginRequestsRepo,
+		jwtKey:                   []byte(jwtKeyString),
 	}
 }
 
 func (s *service) JWTAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenString := c.GetHeader("Authorization")
+		if tokenString == "" && websocket.IsWebSocketUpgrade(c.Request) {
+			tokenString = web_sockets.AuthToken(c.Request)
+		}
 		if tokenString == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
 			return
 		}
 
+		if strings.HasPrefix(tokenString, personalAccessTokenPrefix) {
+			s.authenticatePersonalAccessToken(c, tokenString)
+			return
+		}
+
 		claims := &claims{}
 		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 			return s.jwtKey, nil
-		})
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
-		if err != nil || !token.Valid {
+		if err != nil || !token.Valid || claims.SessionID == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
 			return
 		}
 
+		session, err := s.sessionsRepo.GetSessionByID(c, claims.SessionID)
+		if err != nil {
+			if s.sessionsRepo.IsErrNoRows(err) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !session.IsActive() || session.UserID != claims.UserID {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "session revoked"})
+			return
+		}
+
 		c.Set("user_id", claims.UserID)
+		c.Set("session_id", claims.SessionID)
 		c.Next()
 	}
 }
 
-func (s *service) Login(ctx context.Context, email string, password string) (*entities.User, string, error) {
+func (s *service) authenticatePersonalAccessToken(c *gin.Context, tokenString string) {
+	token, err := s.personalAccessTokensRepo.GetTokenByHash(c, secure_token.Hash(tokenString))
+	if err != nil {
+		if s.personalAccessTokensRepo.IsErrNoRows(err) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !token.IsActive() {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token revoked or expired"})
+		return
+	}
+	if _, err := s.usersService.FindUserByID(c, token.UserID); err != nil {
+		if users.IsErrUserNotFound(err) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > personalAccessTokenTouchInterval {
+		if err := s.personalAccessTokensRepo.TouchToken(c, token.ID, now); err != nil {
+			log.Printf("Error updating last usage of personal access token %d: %v", token.ID, err)
+		}
+	}
+
+	c.Set("user_id", token.UserID)
+	c.Set(entities.TokenScopeContextKey, token.Scope())
+	c.Next()
+}
+
+func (s *service) Login(
+	ctx context.Context,
+	email string,
+	password string,
+	client entities.SessionClient,
+) (*entities.User, *entities.AuthTokens, error) {
+	lockoutKey := loginLockoutKeyPrefix + strings.ToLower(email)
+	lockout, err := s.rateLimiter.Check(ctx, lockoutKey, loginFailuresLimit)
+	if err != nil {
+		log.Printf("Error checking login lockout: %v", err)
+	} else if !lockout.Allowed {
+		return nil, nil, ErrorTooManyLoginAttempts{RetryAfter: lockout.RetryAfter}
+	}
+
 	user, err := s.usersService.FindUserByEmail(ctx, email)
 	if err != nil {
-		return nil, "", err
+		if users.IsErrUserNotFound(err) {
+			s.registerLoginFailure(ctx, lockoutKey)
+		}
+		return nil, nil, err
 	}
 
-	if user.Password != password {
-		return nil, "", ErrorWrongPassword{}
+	ok, err := s.usersService.VerifyPassword(ctx, user, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		s.registerLoginFailure(ctx, lockoutKey)
+		return nil, nil, ErrorWrongPassword{}
+	}
+
+	if err := s.rateLimiter.Reset(ctx, lockoutKey); err != nil {
+		log.Printf("Error resetting login lockout: %v", err)
+	}
+
+	tokens, err := s.createSession(ctx, user.ID, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+func (s *service) StartOIDCLogin(ctx context.Context) (string, error) {
+	if s.oidcProvider == nil {
+		return "", ErrorOIDCDisabled{}
 	}
 
-	token, err := s.generateToken(user.ID)
+	if err := s.oidcLoginRequestsRepo.DeleteExpiredLoginRequests(ctx); err != nil {
+		log.Printf("Error deleting expired OIDC login requests: %v", err)
+	}
+
+	request := &entities.OIDCLoginRequest{
+		ExpiresAt: time.Now().Add(oidcLoginRequestExpirationTime),
+	}
+	for _, value := range []*string{&request.State, &request.Nonce, &request.CodeVerifier} {
+		token, err := secure_token.Generate("")
+		if err != nil {
+			return "", err
+		}
+		*value = token
+	}
+
+	authURL, err := s.oidcProvider.AuthCodeURL(ctx, request.State, request.Nonce, request.CodeVerifier)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.oidcLoginRequestsRepo.CreateLoginRequest(ctx, request); err != nil {
+		return "", err
+	}
+
+	return authURL, nil
+}
+
+func (s *service) CompleteOIDCLogin(
+	ctx context.Context,
+	state string,
+	code string,
+	client entities.SessionClient,
+) (*entities.User, *entities.AuthTokens, error) {
+	if s.oidcProvider == nil {
+		return nil, nil, ErrorOIDCDisabled{}
+	}
+
+	request, err := s.oidcLoginRequestsRepo.ConsumeLoginRequest(ctx, state)
+	if err != nil {
+		if s.oidcLoginRequestsRepo.IsErrNoRows(err) {
+			return nil, nil, ErrorInvalidOIDCState{}
+		}
+		return nil, nil, err
+	}
+
+	claims, err := s.oidcProvider.Exchange(ctx, code, request.CodeVerifier, request.Nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.usersService.ProvisionExternalUser(ctx, &entities.ExternalIdentity{
+		Issuer:        s.oidcProvider.Issuer(),
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.createSession(ctx, user.ID, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+func (s *service) registerLoginFailure(ctx context.Context, lockoutKey string) {
+	if _, err := s.rateLimiter.Allow(ctx, lockoutKey, loginFailuresLimit); err != nil {
+		log.Printf("Error registering failed login: %v", err)
+	}
+}
+
+func (s *service) Refresh(ctx context.Context, refreshToken string, client entities.SessionClient) (*entities.AuthTokens, error) {
+	hash := secure_token.Hash(refreshToken)
+	session, err := s.sessionsRepo.GetSessionByRefreshTokenHash(ctx, hash)
+	if err != nil {
+		if s.sessionsRepo.IsErrNoRows(err) {
+			return nil, ErrorInvalidRefreshToken{}
+		}
+		return nil, err
+	}
+
+	if !session.IsActive() {
+		return nil, ErrorInvalidRefreshToken{}
+	}
+
+	if session.RefreshTokenHash != hash {
+		// An already rotated token is being reused, so it has probably leaked.
+		log.Printf("Refresh token reuse detected for session %s, revoking it", session.ID)
+		if err := s.sessionsRepo.RevokeSession(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrorInvalidRefreshToken{}
+	}
+
+	newRefreshToken, err := secure_token.Generate("")
+	if err != nil {
+		return nil, err
+	}
+
+	session, err = s.sessionsRepo.RotateRefreshToken(
+		ctx,
+		session.ID,
+		hash,
+		secure_token.Hash(newRefreshToken),
+		time.Now().Add(refreshTokenExpirationTime),
+		client,
+	)
+	if err != nil {
+		if s.sessionsRepo.IsErrNoRows(err) {
+			return nil, ErrorInvalidRefreshToken{}
+		}
+		return nil, err
+	}
+
+	return s.issueTokens(session, newRefreshToken)
+}
+
+func (s *service) Logout(ctx context.Context, sessionID string) error {
+	return s.sessionsRepo.RevokeSession(ctx, sessionID)
+}
+
+func (s *service) LogoutAll(ctx context.Context, userID int64) error {
+	return s.sessionsRepo.RevokeUserSessions(ctx, userID)
+}
+
+func (s *service) LogoutOthers(ctx context.Context, userID int64, sessionID string) error {
+	return s.sessionsRepo.RevokeOtherUserSessions(ctx, userID, sessionID)
+}
+
+func (s *service) ListSessions(ctx context.Context, userID int64) ([]*entities.Session, error) {
+	return s.sessionsRepo.ListActiveUserSessions(ctx, userID)
+}
+
+func (s *service) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	session, err := s.sessionsRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		if s.sessionsRepo.IsErrNoRows(err) {
+			return ErrorSessionNotFound{}
+		}
+		return err
+	}
+	if session.UserID != userID {
+		return ErrorSessionNotFound{}
+	}
+
+	return s.sessionsRepo.RevokeSession(ctx, sessionID)
+}
+
+func (s *service) CreatePersonalAccessToken(
+	ctx context.Context,
+	token *entities.PersonalAccessToken,
+) (*entities.PersonalAccessToken, string, error) {
+	tokenString, err := secure_token.Generate(personalAccessTokenPrefix)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return user, token, nil
+	token.TokenHash = secure_token.Hash(tokenString)
+	token.TokenPrefix = tokenString[:personalAccessTokenDisplayLength]
+
+	createdToken, err := s.personalAccessTokensRepo.CreateToken(ctx, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return createdToken, tokenString, nil
+}
+
+func (s *service) ListPersonalAccessTokens(ctx context.Context, userID int64) ([]*entities.PersonalAccessToken, error) {
+	return s.personalAccessTokensRepo.ListUserTokens(ctx, userID)
+}
+
+func (s *service) RevokePersonalAccessToken(ctx context.Context, userID int64, tokenID int64) error {
+	revoked, err := s.personalAccessTokensRepo.RevokeToken(ctx, userID, tokenID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrorPersonalAccessTokenNotFound{}
+	}
+	return nil
+}
+
+func (s *service) RevokeAllPersonalAccessTokens(ctx context.Context, userID int64) error {
+	return s.personalAccessTokensRepo.RevokeUserTokens(ctx, userID)
+}
+
+func (s *service) createSession(ctx context.Context, userID int64, client entities.SessionClient) (*entities.AuthTokens, error) {
+	refreshToken, err := secure_token.Generate("")
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionsRepo.CreateSession(ctx, &entities.Session{
+		ID:               uuid.New().String(),
+		UserID:           userID,
+		RefreshTokenHash: secure_token.Hash(refreshToken),
+		UserAgent:        client.UserAgent,
+		IP:               client.IP,
+		ExpiresAt:        time.Now().Add(refreshTokenExpirationTime),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(session, refreshToken)
+}
+
+func (s *service) issueTokens(session *entities.Session, refreshToken string) (*entities.AuthTokens, error) {
+	expirationTime := time.Now().Add(accessTokenExpirationTime)
+	accessToken, err := s.generateToken(session.UserID, session.ID, expirationTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.AuthTokens{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: expirationTime,
+		RefreshToken:         refreshToken,
+		SessionID:            session.ID,
+	}, nil
 }
 
-func (s *service) generateToken(userID int64) (string, error) {
-	expirationTime := time.Now().Add(tokenExpirationTime)
+func (s *service) generateToken(userID int64, sessionID string, expirationTime time.Time) (string, error) {
 	claims := &claims{
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
@@ -82,6 +438,7 @@ func (s *service) generateToken(userID int64) (string, error) {
 }
 
 type claims struct {
-	UserID int64 `json:"user_id"`
+	UserID    int64  `json:"user_id"`
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
@@ -7,6 +7,9 @@ import (
 	"context"
 )
 
+// copyBatchSize keeps the inserts of copied items under the limit of query parameters
+const copyBatchSize = 5000
+
 type service struct {
 	repo repositories.IChangelogRepository
 }
@@ -36,3 +39,37 @@ func (s *service) ListChangelogForTable(
 ) ([]*entities.ChangelogItemWithUserInfo, error) {
 	return s.repo.ListChangelogForTable(ctx, tableID)
 }
+
+func (s *service) ListChangelogForDatabase(
+	ctx context.Context,
+	databaseID int64,
+) ([]*entities.ChangelogItemWithUserInfo, error) {
+	return s.repo.ListChangelogForDatabase(ctx, databaseID)
+}
+
+func (s *service) CopyTableChangelog(ctx context.Context, sourceTableID, targetTableID string, columnIDs map[string]string) error {
+	items, err := s.repo.ListTableItems(ctx, sourceTableID)
+	if err != nil {
+		return err
+	}
+
+	batch := make([]*entities.ChangelogItem, 0, copyBatchSize)
+	for _, item := range items {
+		copiedItem, ok := item.CopyFor(targetTableID, columnIDs)
+		if !ok {
+			continue
+		}
+		batch = append(batch, copiedItem)
+
+		if len(batch) == copyBatchSize {
+			if err := s.repo.AddChangelogItems(ctx, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return s.repo.AddChangelogItems(ctx, batch)
+}
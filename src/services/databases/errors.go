@@ -0,0 +1,124 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"errors"
+	"strings"
+)
+
+type ErrorDatabaseNotFound struct{}
+
+func (e ErrorDatabaseNotFound) Error() string {
+	return "Database not found"
+}
+
+func IsErrDatabaseNotFound(err error) bool {
+	target := ErrorDatabaseNotFound{}
+	return errors.As(err, &target)
+}
+
+type ErrorLastAdmin struct{}
+
+func (e ErrorLastAdmin) Error() string {
+	return "Database must keep at least one admin"
+}
+
+func IsErrLastAdmin(err error) bool {
+	target := ErrorLastAdmin{}
+	return errors.As(err, &target)
+}
+
+type ErrorOwnerMustStayAdmin struct{}
+
+func (e ErrorOwnerMustStayAdmin) Error() string {
+	return "Owner must stay an admin of the database, transfer the ownership first"
+}
+
+func IsErrOwnerMustStayAdmin(err error) bool {
+	target := ErrorOwnerMustStayAdmin{}
+	return errors.As(err, &target)
+}
+
+type ErrorNotMember struct{}
+
+func (e ErrorNotMember) Error() string {
+	return "User is not a member of the database"
+}
+
+func IsErrNotMember(err error) bool {
+	target := ErrorNotMember{}
+	return errors.As(err, &target)
+}
+
+type ErrorTableRoleForAdmin struct{}
+
+func (e ErrorTableRoleForAdmin) Error() string {
+	return "Database admins have full access to every table, table roles do not apply to them"
+}
+
+func IsErrTableRoleForAdmin(err error) bool {
+	target := ErrorTableRoleForAdmin{}
+	return errors.As(err, &target)
+}
+
+type ErrorRoleNotFound struct{}
+
+func (e ErrorRoleNotFound) Error() string {
+	return "Role not found"
+}
+
+func IsErrRoleNotFound(err error) bool {
+	target := ErrorRoleNotFound{}
+	return errors.As(err, &target)
+}
+
+type ErrorRoleExists struct{}
+
+func (e ErrorRoleExists) Error() string {
+	return "Role already exists"
+}
+
+func IsErrRoleExists(err error) bool {
+	target := ErrorRoleExists{}
+	return errors.As(err, &target)
+}
+
+type ErrorBuiltinRole struct{}
+
+func (e ErrorBuiltinRole) Error() string {
+	return "Built-in roles cannot be changed"
+}
+
+func IsErrBuiltinRole(err error) bool {
+	target := ErrorBuiltinRole{}
+	return errors.As(err, &target)
+}
+
+type ErrorRoleInUse struct{}
+
+func (e ErrorRoleInUse) Error() string {
+	return "Role is assigned to members, table overrides or pending invitations"
+}
+
+func IsErrRoleInUse(err error) bool {
+	target := ErrorRoleInUse{}
+	return errors.As(err, &target)
+}
+
+type ErrorCannotLeaveDatabases struct {
+	Databases []*entities.Database
+}
+
+func (e ErrorCannotLeaveDatabases) Error() string {
+	names := make([]string, 0, len(e.Databases))
+	for _, db := range e.Databases {
+		names = append(names, db.Name)
+	}
+	return "User owns or is the only admin of databases: " + strings.Join(names, ", ")
+}
+
+func IsErrCannotLeaveDatabases(err error) (ErrorCannotLeaveDatabases, bool) {
+	target := ErrorCannotLeaveDatabases{}
+	ok := errors.As(err, &target)
+	return target, ok
+}
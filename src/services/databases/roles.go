@@ -0,0 +1,194 @@
+package databases
+
+import (
+	"backend/src/domains/entities"
+	"context"
+)
+
+func (s *service) GetRolePermissions(ctx context.Context, databaseID int64, role entities.Role) (entities.Permissions, error) {
+	if permissions, ok := role.BuiltinPermissions(); ok {
+		return permissions, nil
+	}
+	if role == "" {
+		return entities.Permissions{}, nil
+	}
+
+	databaseRole, err := s.rolesRepo.GetRole(ctx, databaseID, role)
+	if err != nil {
+		// roles in use cannot be deleted, an unknown role grants nothing
+		if s.rolesRepo.IsErrNoRows(err) {
+			return entities.Permissions{}, nil
+		}
+		return nil, err
+	}
+	return databaseRole.GetPermissions(), nil
+}
+
+func (s *service) CanGrantRole(ctx context.Context, userID, databaseID int64, role entities.Role) (bool, error) {
+	if role != entities.RoleNone && !role.IsBuiltin() {
+		if _, err := s.getRole(ctx, databaseID, role); err != nil {
+			return false, err
+		}
+	}
+
+	grantorRole, err := s.GetUsersDatabaseRole(ctx, userID, databaseID)
+	if err != nil {
+		return false, err
+	}
+	if role == entities.RoleAdmin {
+		return grantorRole == entities.RoleAdmin, nil
+	}
+
+	grantorPermissions, err := s.GetUsersDatabasePermissions(ctx, userID, databaseID)
+	if err != nil {
+		return false, err
+	}
+	if !grantorPermissions.Has(entities.PermissionManageMembers) {
+		return false, nil
+	}
+
+	permissions, err := s.GetRolePermissions(ctx, databaseID, role)
+	if err != nil {
+		return false, err
+	}
+	return grantorPermissions.Contains(permissions), nil
+}
+
+func (s *service) CanManageMember(ctx context.Context, userID, databaseID, memberID int64) (bool, error) {
+	memberRole, err := s.repo.GetUsersDatabaseRole(ctx, memberID, databaseID, false)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return s.CheckUserPermission(ctx, userID, databaseID, entities.PermissionManageMembers)
+		}
+		return false, err
+	}
+
+	// a member can only be managed by whoever could have granted their role
+	granted, err := s.CanGrantRole(ctx, userID, databaseID, memberRole)
+	if IsErrRoleNotFound(err) {
+		return s.CheckUserPermission(ctx, userID, databaseID, entities.PermissionManageMembers)
+	}
+	return granted, err
+}
+
+func (s *service) ListDatabaseRoles(ctx context.Context, databaseID int64) ([]*entities.DatabaseRole, error) {
+	return s.rolesRepo.ListDatabaseRoles(ctx, databaseID)
+}
+
+func (s *service) CreateRole(ctx context.Context, role *entities.DatabaseRole) (*entities.DatabaseRole, error) {
+	if role.Name.IsBuiltin() {
+		return nil, ErrorRoleExists{}
+	}
+
+	var createdRole *entities.DatabaseRole
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.lockDatabase(ctx, role.DatabaseID); err != nil {
+			return err
+		}
+
+		_, err := s.rolesRepo.GetRole(ctx, role.DatabaseID, role.Name)
+		if err == nil {
+			return ErrorRoleExists{}
+		}
+		if !s.rolesRepo.IsErrNoRows(err) {
+			return err
+		}
+
+		createdRole, err = s.rolesRepo.CreateRole(ctx, role)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdRole, nil
+}
+
+func (s *service) UpdateRolePermissions(
+	ctx context.Context,
+	databaseID int64,
+	name entities.Role,
+	permissions entities.Permissions,
+) (*entities.DatabaseRole, error) {
+	if name.IsBuiltin() {
+		return nil, ErrorBuiltinRole{}
+	}
+
+	role, err := s.rolesRepo.UpdateRolePermissions(ctx, databaseID, name, permissions)
+	if err != nil {
+		if s.rolesRepo.IsErrNoRows(err) {
+			return nil, ErrorRoleNotFound{}
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *service) DeleteRole(ctx context.Context, databaseID int64, name entities.Role) error {
+	if name.IsBuiltin() {
+		return ErrorBuiltinRole{}
+	}
+
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.lockDatabase(ctx, databaseID); err != nil {
+			return err
+		}
+
+		usages, err := s.rolesRepo.CountRoleUsages(ctx, databaseID, name)
+		if err != nil {
+			return err
+		}
+		if usages > 0 {
+			return ErrorRoleInUse{}
+		}
+
+		deleted, err := s.rolesRepo.DeleteRole(ctx, databaseID, name)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return ErrorRoleNotFound{}
+		}
+		return nil
+	})
+}
+
+func (s *service) getRole(ctx context.Context, databaseID int64, name entities.Role) (*entities.DatabaseRole, error) {
+	role, err := s.rolesRepo.GetRole(ctx, databaseID, name)
+	if err != nil {
+		if s.rolesRepo.IsErrNoRows(err) {
+			return nil, ErrorRoleNotFound{}
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+// rolePermissionsCache resolves the permissions of many roles, looking every custom role up once.
+type rolePermissionsCache struct {
+	s     *service
+	cache map[int64]map[entities.Role]entities.Permissions
+}
+
+func newRolePermissionsCache(s *service) *rolePermissionsCache {
+	return &rolePermissionsCache{
+		s:     s,
+		cache: make(map[int64]map[entities.Role]entities.Permissions),
+	}
+}
+
+func (c *rolePermissionsCache) get(ctx context.Context, databaseID int64, role entities.Role) (entities.Permissions, error) {
+	if permissions, ok := c.cache[databaseID][role]; ok {
+		return permissions, nil
+	}
+
+	permissions, err := c.s.GetRolePermissions(ctx, databaseID, role)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.cache[databaseID]; !ok {
+		c.cache[databaseID] = make(map[entities.Role]entities.Permissions)
+	}
+	c.cache[databaseID][role] = permissions
+	return permissions, nil
+}
@@ -3,50 +3,328 @@ package databases
 import (
 	"backend/src/domains/entities"
 	"backend/src/domains/repositories"
+	"backend/src/modules/sql_executor"
 	"backend/src/services"
 	"context"
+	"sort"
 )
 
 type service struct {
-	repo repositories.IDatabasesRepository
+	executor        sql_executor.ISQLExecutor
+	repo            repositories.IDatabasesRepository
+	usersTablesRepo repositories.IUsersTablesRepository
+	rolesRepo       repositories.IDatabaseRolesRepository
+	// organizationsRepo resolves the implicit admin role of organization admins.
+	organizationsRepo repositories.IOrganizationsRepository
+	changelogService  services.IChangelogService
 }
 
-func NewService(repo repositories.IDatabasesRepository) services.IDatabasesService {
+func NewService(
+	executor sql_executor.ISQLExecutor,
+	repo repositories.IDatabasesRepository,
+	usersTablesRepo repositories.IUsersTablesRepository,
+	rolesRepo repositories.IDatabaseRolesRepository,
+	organizationsRepo repositories.IOrganizationsRepository,
+	changelogService services.IChangelogService,
+) services.IDatabasesService {
 	return &service{
-		repo: repo,
+		executor:          executor,
+		repo:              repo,
+		usersTablesRepo:   usersTablesRepo,
+		rolesRepo:         rolesRepo,
+		organizationsRepo: organizationsRepo,
+		changelogService:  changelogService,
 	}
 }
 
-func (s *service) AddDatabase(ctx context.Context, userID int64, name string) (*entities.Database, error) {
-	createdDatabase, err := s.repo.AddDatabase(ctx, name)
+func (s *service) AddDatabase(ctx context.Context, userID int64, name string, organizationID *int64) (*entities.Database, error) {
+	var createdDatabase *entities.Database
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdDatabase, err = s.repo.AddDatabase(ctx, name, userID, organizationID)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.repo.UpsertUsersDatabase(ctx, &entities.UsersDatabase{
+			UserID:     userID,
+			DatabaseID: createdDatabase.ID,
+			Role:       entities.RoleAdmin,
+		})
+		if err != nil {
+			return err
+		}
+
+		databaseChange := &entities.DatabaseChange{
+			ChangeType: entities.ChangeTypeAdd,
+			After:      &entities.DatabaseInfoForChangelog{Name: createdDatabase.Name},
+		}
+		return s.changelogService.WriteChangelog(ctx, databaseChange.ToChangelogItem(userID, createdDatabase.ID))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	usersDatabase := &entities.UsersDatabase{
-		UserID:     userID,
-		DatabaseID: createdDatabase.ID,
-		Role:       entities.RoleAdmin,
+	return createdDatabase, nil
+}
+
+func (s *service) UpsertUsersDatabase(ctx context.Context, usersDatabase *entities.UsersDatabase) (*entities.UsersDatabase, error) {
+	var upsertedUsersDatabase *entities.UsersDatabase
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if usersDatabase.Role != entities.RoleAdmin {
+			if err := s.checkAdminCanLeave(ctx, usersDatabase.UserID, usersDatabase.DatabaseID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		upsertedUsersDatabase, err = s.repo.UpsertUsersDatabase(ctx, usersDatabase)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = s.UpsertUsersDatabase(ctx, usersDatabase)
+	return upsertedUsersDatabase, nil
+}
+
+// SetUsersRole grants the role to the user and logs the change as made by the actor. The database
+// row is locked first, so that the logged previous role cannot be changed concurrently.
+func (s *service) SetUsersRole(
+	ctx context.Context,
+	actorID int64,
+	usersDatabase *entities.UsersDatabase,
+) (*entities.UsersDatabase, error) {
+	var upsertedUsersDatabase *entities.UsersDatabase
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.lockDatabase(ctx, usersDatabase.DatabaseID); err != nil {
+			return err
+		}
+
+		oldRole, err := s.GetGrantedRole(ctx, usersDatabase.UserID, usersDatabase.DatabaseID)
+		if err != nil {
+			return err
+		}
+
+		upsertedUsersDatabase, err = s.UpsertUsersDatabase(ctx, usersDatabase)
+		if err != nil {
+			return err
+		}
+
+		memberChange := entities.NewMemberChange(usersDatabase.UserID, oldRole, usersDatabase.Role)
+		return s.changelogService.WriteChangelog(ctx, memberChange.ToChangelogItem(actorID, usersDatabase.DatabaseID))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return createdDatabase, nil
+	return upsertedUsersDatabase, nil
 }
 
-func (s *service) UpsertUsersDatabase(ctx context.Context, usersDatabase *entities.UsersDatabase) (*entities.UsersDatabase, error) {
-	return s.repo.UpsertUsersDatabase(ctx, usersDatabase)
+func (s *service) DeleteUsersDatabaseRelation(ctx context.Context, actorID, userID, databaseID int64) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkAdminCanLeave(ctx, userID, databaseID); err != nil {
+			return err
+		}
+
+		// the database row is locked by checkAdminCanLeave
+		oldRole, err := s.GetGrantedRole(ctx, userID, databaseID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.usersTablesRepo.DeleteUsersDatabaseTables(ctx, userID, databaseID); err != nil {
+			return err
+		}
+
+		if err := s.repo.DeleteUsersDatabaseRelation(ctx, userID, databaseID); err != nil {
+			return err
+		}
+
+		if oldRole == "" {
+			return nil
+		}
+		memberChange := entities.NewMemberChange(userID, oldRole, "")
+		return s.changelogService.WriteChangelog(ctx, memberChange.ToChangelogItem(actorID, databaseID))
+	})
+}
+
+func (s *service) DeleteUsersMemberships(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error) {
+	var memberships []*entities.UsersDatabase
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		memberships, err = s.repo.LockUsersMemberships(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		blockingDatabases, err := s.repo.ListDatabasesUserCannotLeave(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(blockingDatabases) > 0 {
+			return ErrorCannotLeaveDatabases{Databases: blockingDatabases}
+		}
+
+		for _, membership := range memberships {
+			if err := s.usersTablesRepo.DeleteUsersDatabaseTables(ctx, userID, membership.DatabaseID); err != nil {
+				return err
+			}
+			if err := s.repo.DeleteUsersDatabaseRelation(ctx, userID, membership.DatabaseID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+func (s *service) TransferOwnership(ctx context.Context, actorID, databaseID, newOwnerID int64) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.lockDatabase(ctx, databaseID); err != nil {
+			return err
+		}
+
+		role, err := s.repo.GetUsersDatabaseRole(ctx, newOwnerID, databaseID, false)
+		if err != nil {
+			if s.repo.IsErrNoRows(err) {
+				return ErrorNotMember{}
+			}
+			return err
+		}
+
+		// the new owner is made an admin by the transfer
+		if role != entities.RoleAdmin {
+			_, err = s.repo.UpsertUsersDatabase(ctx, &entities.UsersDatabase{
+				UserID:     newOwnerID,
+				DatabaseID: databaseID,
+				Role:       entities.RoleAdmin,
+			})
+			if err != nil {
+				return err
+			}
+
+			memberChange := entities.NewMemberChange(newOwnerID, role, entities.RoleAdmin)
+			if err := s.changelogService.WriteChangelog(ctx, memberChange.ToChangelogItem(actorID, databaseID)); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.SetDatabaseOwner(ctx, databaseID, newOwnerID)
+	})
+}
+
+// checkAdminCanLeave makes sure that the user losing the admin role is neither the owner
+// nor the last admin. It must run inside a transaction, as it locks the database row
+// to keep concurrent demotions from removing all admins together.
+func (s *service) checkAdminCanLeave(ctx context.Context, userID, databaseID int64) error {
+	database, err := s.lockDatabase(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+	if database.OwnerID != nil && *database.OwnerID == userID {
+		return ErrorOwnerMustStayAdmin{}
+	}
+
+	role, err := s.repo.GetUsersDatabaseRole(ctx, userID, databaseID, false)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil
+		}
+		return err
+	}
+	if role != entities.RoleAdmin {
+		return nil
+	}
+
+	otherAdmins, err := s.repo.CountOtherAdmins(ctx, databaseID, userID)
+	if err != nil {
+		return err
+	}
+	if otherAdmins == 0 {
+		return ErrorLastAdmin{}
+	}
+	return nil
 }
 
-func (s *service) DeleteUsersDatabaseRelation(ctx context.Context, userID, databaseID int64) error {
-	return s.repo.DeleteUsersDatabaseRelation(ctx, userID, databaseID)
+func (s *service) lockDatabase(ctx context.Context, databaseID int64) (*entities.Database, error) {
+	database, err := s.repo.LockDatabase(ctx, databaseID)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, ErrorDatabaseNotFound{}
+		}
+		return nil, err
+	}
+	return database, nil
 }
 
 func (s *service) GetUsersDatabases(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error) {
-	return s.repo.GetUsersDatabases(ctx, userID)
+	usersDatabases, err := s.getUsersDatabases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := entities.TokenScopeFromContext(ctx)
+	if scope == nil {
+		return usersDatabases, nil
+	}
+
+	res := make([]*entities.UsersDatabase, 0, len(usersDatabases))
+	for _, usersDatabase := range usersDatabases {
+		if !scope.AllowsDatabase(usersDatabase.DatabaseID) {
+			continue
+		}
+		usersDatabase.Role = scope.LimitRole(usersDatabase.DatabaseID, usersDatabase.Role)
+		res = append(res, usersDatabase)
+	}
+	return res, nil
+}
+
+// getUsersDatabases adds the databases of the organizations the user administers to the ones the user is a member of.
+func (s *service) getUsersDatabases(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error) {
+	usersDatabases, err := s.repo.GetUsersDatabases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	administeredDatabases, err := s.organizationsRepo.GetAdministeredDatabases(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	return mergeAdministeredDatabases(userID, usersDatabases, administeredDatabases), nil
+}
+
+func mergeAdministeredDatabases(
+	userID int64,
+	usersDatabases []*entities.UsersDatabase,
+	administeredDatabases []*entities.Database,
+) []*entities.UsersDatabase {
+	byID := make(map[int64]*entities.UsersDatabase, len(usersDatabases))
+	for _, usersDatabase := range usersDatabases {
+		byID[usersDatabase.DatabaseID] = usersDatabase
+	}
+
+	for _, database := range administeredDatabases {
+		if usersDatabase, ok := byID[database.ID]; ok {
+			usersDatabase.Role = entities.RoleAdmin
+			continue
+		}
+		usersDatabases = append(usersDatabases, &entities.UsersDatabase{
+			UserID:            userID,
+			DatabaseID:        database.ID,
+			Role:              entities.RoleAdmin,
+			CreatedAt:         database.CreatedAt,
+			Name:              database.Name,
+			OwnerID:           database.OwnerID,
+			OrganizationID:    database.OrganizationID,
+			DatabaseDeletedAt: database.DeletedAt,
+		})
+	}
+	return usersDatabases
 }
 
 func (s *service) GetDatabasesUsers(ctx context.Context, databaseID int64) ([]*entities.DatabasesUser, error) {
@@ -58,11 +336,17 @@ func (s *service) GetDatabasesUsersIDs(ctx context.Context, databaseID int64) ([
 }
 
 func (s *service) CheckUserRole(ctx context.Context, userID, databaseID int64, requiredRole entities.Role) (bool, error) {
-	role, err := s.repo.GetUsersDatabaseRole(ctx, userID, databaseID)
+	role, err := s.GetUsersDatabaseRole(ctx, userID, databaseID)
+	if err != nil {
+		return false, err
+	}
+
+	return role.Authorize(requiredRole), nil
+}
+
+func (s *service) CheckUserRoleWithDeleted(ctx context.Context, userID, databaseID int64, requiredRole entities.Role) (bool, error) {
+	role, err := s.getUsersDatabaseRole(ctx, userID, databaseID, true)
 	if err != nil {
-		if s.repo.IsErrNoRows(err) {
-			return false, nil
-		}
 		return false, err
 	}
 
@@ -70,7 +354,22 @@ func (s *service) CheckUserRole(ctx context.Context, userID, databaseID int64, r
 }
 
 func (s *service) GetUsersDatabaseRole(ctx context.Context, userID, databaseID int64) (entities.Role, error) {
-	role, err := s.repo.GetUsersDatabaseRole(ctx, userID, databaseID)
+	return s.getUsersDatabaseRole(ctx, userID, databaseID, false)
+}
+
+func (s *service) getUsersDatabaseRole(ctx context.Context, userID, databaseID int64, withDeleted bool) (entities.Role, error) {
+	role, err := s.getMemberRole(ctx, userID, databaseID, withDeleted)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return entities.TokenScopeFromContext(ctx).LimitRole(databaseID, role), nil
+}
+
+func (s *service) GetGrantedRole(ctx context.Context, userID, databaseID int64) (entities.Role, error) {
+	role, err := s.repo.GetUsersDatabaseRole(ctx, userID, databaseID, false)
 	if err != nil {
 		if s.repo.IsErrNoRows(err) {
 			return "", nil
@@ -79,3 +378,256 @@ func (s *service) GetUsersDatabaseRole(ctx context.Context, userID, databaseID i
 	}
 	return role, nil
 }
+
+// getMemberRole returns the role of the user in the database, which is admin for the admins of its organization.
+// Like the repository, it fails with no rows for users without access.
+func (s *service) getMemberRole(ctx context.Context, userID, databaseID int64, withDeleted bool) (entities.Role, error) {
+	role, err := s.repo.GetUsersDatabaseRole(ctx, userID, databaseID, withDeleted)
+	if err != nil && !s.repo.IsErrNoRows(err) {
+		return "", err
+	}
+	if role == entities.RoleAdmin {
+		return role, nil
+	}
+
+	isOrganizationAdmin, orgErr := s.organizationsRepo.IsDatabaseOrganizationAdmin(ctx, userID, databaseID, withDeleted)
+	if orgErr != nil {
+		return "", orgErr
+	}
+	if isOrganizationAdmin {
+		return entities.RoleAdmin, nil
+	}
+	return role, err
+}
+
+func (s *service) ListDatabasesUserCannotLeave(ctx context.Context, userID int64) ([]*entities.Database, error) {
+	return s.repo.ListDatabasesUserCannotLeave(ctx, userID)
+}
+
+func (s *service) GetDatabaseByID(ctx context.Context, id int64) (*entities.Database, error) {
+	database, err := s.repo.GetDatabaseByID(ctx, id)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, ErrorDatabaseNotFound{}
+		}
+		return nil, err
+	}
+	return database, nil
+}
+
+func (s *service) RenameDatabase(ctx context.Context, actorID, id int64, name string) (*entities.Database, error) {
+	var database *entities.Database
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		oldDatabase, err := s.lockDatabase(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		database, err = s.repo.RenameDatabase(ctx, id, name)
+		if err != nil {
+			return err
+		}
+
+		databaseChange := &entities.DatabaseChange{
+			ChangeType: entities.ChangeTypeUpdate,
+			Before:     &entities.DatabaseInfoForChangelog{Name: oldDatabase.Name},
+			After:      &entities.DatabaseInfoForChangelog{Name: database.Name},
+		}
+		return s.changelogService.WriteChangelog(ctx, databaseChange.ToChangelogItem(actorID, id))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+func (s *service) DeleteDatabase(ctx context.Context, id int64) error {
+	return s.repo.DeleteDatabase(ctx, id)
+}
+
+func (s *service) RestoreDatabase(ctx context.Context, id int64) error {
+	return s.repo.RestoreDatabase(ctx, id)
+}
+
+func (s *service) GetUsersDeletedDatabases(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error) {
+	usersDatabases, err := s.repo.GetUsersDeletedDatabases(ctx, userID, entities.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	administeredDatabases, err := s.organizationsRepo.GetAdministeredDatabases(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(administeredDatabases) > 0 {
+		usersDatabases = mergeAdministeredDatabases(userID, usersDatabases, administeredDatabases)
+		sort.SliceStable(usersDatabases, func(i, j int) bool {
+			return usersDatabases[i].DatabaseDeletedAt.After(*usersDatabases[j].DatabaseDeletedAt)
+		})
+	}
+
+	scope := entities.TokenScopeFromContext(ctx)
+	if scope == nil {
+		return usersDatabases, nil
+	}
+
+	res := make([]*entities.UsersDatabase, 0, len(usersDatabases))
+	for _, usersDatabase := range usersDatabases {
+		if scope.LimitRole(usersDatabase.DatabaseID, usersDatabase.Role) == entities.RoleAdmin {
+			res = append(res, usersDatabase)
+		}
+	}
+	return res, nil
+}
+
+func (s *service) CheckUserPermission(ctx context.Context, userID, databaseID int64, permission entities.Permission) (bool, error) {
+	permissions, err := s.GetUsersDatabasePermissions(ctx, userID, databaseID)
+	if err != nil {
+		return false, err
+	}
+
+	return permissions.Has(permission), nil
+}
+
+func (s *service) GetUsersDatabasePermissions(ctx context.Context, userID, databaseID int64) (entities.Permissions, error) {
+	role, err := s.getMemberRole(ctx, userID, databaseID, false)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return entities.Permissions{}, nil
+		}
+		return nil, err
+	}
+
+	permissions, err := s.GetRolePermissions(ctx, databaseID, role)
+	if err != nil {
+		return nil, err
+	}
+	return entities.TokenScopeFromContext(ctx).LimitPermissions(databaseID, permissions), nil
+}
+
+func (s *service) CheckUserTablePermission(ctx context.Context, userID int64, table *entities.Table, permission entities.Permission) (bool, error) {
+	_, permissions, err := s.GetUsersTablePermissions(ctx, userID, table)
+	if err != nil {
+		return false, err
+	}
+
+	return permissions.Has(permission), nil
+}
+
+func (s *service) GetUsersTableRole(ctx context.Context, userID int64, table *entities.Table) (entities.Role, error) {
+	role, err := s.getUsersTableRole(ctx, userID, table)
+	if err != nil {
+		return "", err
+	}
+	return entities.TokenScopeFromContext(ctx).LimitRole(table.DatabaseID, role), nil
+}
+
+func (s *service) GetUsersTablePermissions(ctx context.Context, userID int64, table *entities.Table) (entities.Role, entities.Permissions, error) {
+	role, err := s.getUsersTableRole(ctx, userID, table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	permissions, err := s.GetRolePermissions(ctx, table.DatabaseID, role)
+	if err != nil {
+		return "", nil, err
+	}
+
+	scope := entities.TokenScopeFromContext(ctx)
+	return scope.LimitRole(table.DatabaseID, role), scope.LimitPermissions(table.DatabaseID, permissions), nil
+}
+
+// getUsersTableRole returns the role of the user in the table, not limited by the token scope.
+func (s *service) getUsersTableRole(ctx context.Context, userID int64, table *entities.Table) (entities.Role, error) {
+	databaseRole, err := s.getMemberRole(ctx, userID, table.DatabaseID, false)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	tableRole, err := s.usersTablesRepo.GetUsersTableRole(ctx, userID, table.ID)
+	if err != nil && !s.usersTablesRepo.IsErrNoRows(err) {
+		return "", err
+	}
+
+	return entities.EffectiveTableRole(databaseRole, tableRole), nil
+}
+
+func (s *service) FilterReadableTables(ctx context.Context, userID int64, tables []*entities.Table) ([]*entities.Table, error) {
+	usersDatabases, err := s.getUsersDatabases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	databaseRoles := make(map[int64]entities.Role, len(usersDatabases))
+	for _, usersDatabase := range usersDatabases {
+		databaseRoles[usersDatabase.DatabaseID] = usersDatabase.Role
+	}
+
+	usersTables, err := s.usersTablesRepo.GetUsersTables(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tableRoles := make(map[string]entities.Role, len(usersTables))
+	for _, usersTable := range usersTables {
+		tableRoles[usersTable.TableID] = usersTable.Role
+	}
+
+	scope := entities.TokenScopeFromContext(ctx)
+	rolePermissions := newRolePermissionsCache(s)
+	res := make([]*entities.Table, 0, len(tables))
+	for _, table := range tables {
+		role := entities.EffectiveTableRole(databaseRoles[table.DatabaseID], tableRoles[table.ID])
+		permissions, err := rolePermissions.get(ctx, table.DatabaseID, role)
+		if err != nil {
+			return nil, err
+		}
+		if scope.LimitPermissions(table.DatabaseID, permissions).Has(entities.PermissionRead) {
+			role = scope.LimitRole(table.DatabaseID, role)
+			res = append(res, table.ForViewer(entities.TableViewer{UserID: userID, Role: role}))
+		}
+	}
+	return res, nil
+}
+
+func (s *service) SetUsersTableRole(ctx context.Context, userID int64, table *entities.Table, role entities.Role) (*entities.UsersTable, error) {
+	var usersTable *entities.UsersTable
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.lockDatabase(ctx, table.DatabaseID); err != nil {
+			return err
+		}
+
+		databaseRole, err := s.repo.GetUsersDatabaseRole(ctx, userID, table.DatabaseID, false)
+		if err != nil {
+			if s.repo.IsErrNoRows(err) {
+				return ErrorNotMember{}
+			}
+			return err
+		}
+		if databaseRole == entities.RoleAdmin {
+			return ErrorTableRoleForAdmin{}
+		}
+
+		usersTable, err = s.usersTablesRepo.UpsertUsersTable(ctx, &entities.UsersTable{
+			UserID:  userID,
+			TableID: table.ID,
+			Role:    role,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return usersTable, nil
+}
+
+func (s *service) DeleteUsersTableRole(ctx context.Context, userID int64, tableID string) error {
+	return s.usersTablesRepo.DeleteUsersTable(ctx, userID, tableID)
+}
+
+func (s *service) GetTablesUsers(ctx context.Context, tableID string) ([]*entities.TablesUser, error) {
+	return s.usersTablesRepo.GetTablesUsers(ctx, tableID)
+}
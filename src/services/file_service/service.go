@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"mime/multipart"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/xuri/excelize/v2"
@@ -16,6 +18,8 @@ import (
 
 const (
 	defaultSheetName = "Sheet1"
+	// dateNumFmt is the built-in "mm-dd-yy" number format
+	dateNumFmt = 14
 )
 
 type service struct {
@@ -161,13 +165,13 @@ func (s *service) CreateExcel(table *entities.Table, data []entities.TableRow) (
 	}
 
 	header := make([]string, 0, len(table.Columns))
-	headerIDs := make([]string, 0, len(table.Columns))
+	columns := make([]*entities.TableColumn, 0, len(table.Columns))
 	for _, col := range table.Columns {
 		if col.DeletedAt != nil {
 			continue
 		}
 		header = append(header, col.Name)
-		headerIDs = append(headerIDs, col.ID)
+		columns = append(columns, col)
 	}
 
 	err = f.SetSheetRow(defaultSheetName, "A1", &header)
@@ -175,17 +179,132 @@ func (s *service) CreateExcel(table *entities.Table, data []entities.TableRow) (
 		return nil, err
 	}
 
+	err = s.setColumnStyles(f, columns)
+	if err != nil {
+		return nil, err
+	}
+
 	for i, row := range data {
-		rowValues := make([]interface{}, 0, len(headerIDs))
-		for _, id := range headerIDs {
-			rowValues = append(rowValues, row[id])
+		rowValues := make([]interface{}, 0, len(columns))
+		for _, col := range columns {
+			rowValues = append(rowValues, excelValue(col, row[col.ID]))
 		}
 
 		err = f.SetSheetRow(defaultSheetName, fmt.Sprintf("A%d", 2+i), &rowValues)
 		if err != nil {
 			return nil, err
 		}
+
+		err = s.setHyperlinks(f, columns, row, 2+i)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return f, nil
 }
+
+// setColumnStyles applies the date number format to the date columns,
+// so the exported dates are shown without the time part.
+func (s *service) setColumnStyles(f *excelize.File, columns []*entities.TableColumn) error {
+	var dateStyle int
+	for i, col := range columns {
+		if col.ValueType() != entities.ColumnTypeDate {
+			continue
+		}
+
+		if dateStyle == 0 {
+			var err error
+			dateStyle, err = f.NewStyle(&excelize.Style{NumFmt: dateNumFmt})
+			if err != nil {
+				return err
+			}
+		}
+
+		name, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		if err = f.SetColStyle(defaultSheetName, name, dateStyle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) setHyperlinks(f *excelize.File, columns []*entities.TableColumn, row entities.TableRow, rowNumber int) error {
+	for i, col := range columns {
+		value, ok := row[col.ID].(string)
+		if !ok || !col.ValidateColumnValue(&value) || value == "" {
+			continue
+		}
+
+		var link string
+		switch col.Type {
+		case entities.ColumnTypeURL:
+			link = value
+		case entities.ColumnTypeEmail:
+			link = "mailto:" + value
+		default:
+			continue
+		}
+
+		cell, err := excelize.CoordinatesToCellName(i+1, rowNumber)
+		if err != nil {
+			return err
+		}
+		if err = f.SetCellHyperLink(defaultSheetName, cell, link, "External"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// excelValue converts the stored text into the native cell type of the column.
+// Values that don't match the column type are written as they are.
+func excelValue(col *entities.TableColumn, value any) any {
+	if records, ok := value.([]*entities.LinkedRecord); ok {
+		return linkedRecordsText(records)
+	}
+	if p, ok := value.(*string); ok {
+		value = pointer.Get(p)
+	}
+
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return value
+	}
+
+	switch col.ValueType() {
+	case entities.ColumnTypeNumeric, entities.ColumnTypeRollup:
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return n
+		}
+	case entities.ColumnTypeBoolean:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case entities.ColumnTypeDate:
+		if t, err := time.Parse(entities.DateLayout, s); err == nil {
+			return t
+		}
+	case entities.ColumnTypeTimestamp:
+		if t, _, err := entities.TryParseTimestamp(s); err == nil {
+			return t
+		}
+	case entities.ColumnTypeMultiSelect:
+		return entities.FormatMultiSelect(entities.ParseMultiSelect(s))
+	}
+	return value
+}
+
+// linkedRecordsText joins the values of the linked rows, as a cell holds a single value.
+func linkedRecordsText(records []*entities.LinkedRecord) string {
+	values := make([]string, 0, len(records))
+	for _, record := range records {
+		if record.Value != nil {
+			values = append(values, *record.Value)
+		}
+	}
+	return strings.Join(values, ", ")
+}
@@ -14,19 +14,63 @@ type IUsersService interface {
 	AddUser(ctx context.Context, user *entities.User) (*entities.User, error)
 	FindUserByEmail(ctx context.Context, email string) (*entities.User, error)
 	FindUserByID(ctx context.Context, id int64) (*entities.User, error)
-	ListUsers(ctx context.Context) ([]*entities.User, error)
+	VerifyPassword(ctx context.Context, user *entities.User, password string) (bool, error)
+	SendEmailVerification(ctx context.Context, user *entities.User) error
+	VerifyEmail(ctx context.Context, token string) (*entities.User, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) (*entities.User, error)
+	// ProvisionExternalUser returns the user linked to the external identity, creating or linking one on first sign-in.
+	ProvisionExternalUser(ctx context.Context, identity *entities.ExternalIdentity) (*entities.User, error)
+	SearchUsers(ctx context.Context, callerID int64, params entities.SearchUsersParams) ([]*entities.User, int64, error)
+	UpdateName(ctx context.Context, userID int64, name string) (*entities.User, error)
+	// RequestEmailChange sends a confirmation link to the new address, the email is changed once it is followed.
+	RequestEmailChange(ctx context.Context, user *entities.User, password string, email string) error
+	ConfirmEmailChange(ctx context.Context, token string) (*entities.User, error)
+	ChangePassword(ctx context.Context, user *entities.User, currentPassword string, newPassword string) error
+	// DeleteUser soft-deletes the account and removes it from all databases.
+	DeleteUser(ctx context.Context, user *entities.User, password string) error
 }
 
 type IAuthService interface {
 	JWTAuthMiddleware() gin.HandlerFunc
-	Login(ctx context.Context, email string, password string) (*entities.User, string, error)
+	Login(ctx context.Context, email string, password string, client entities.SessionClient) (*entities.User, *entities.AuthTokens, error)
+	StartOIDCLogin(ctx context.Context) (string, error)
+	CompleteOIDCLogin(ctx context.Context, state string, code string, client entities.SessionClient) (*entities.User, *entities.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string, client entities.SessionClient) (*entities.AuthTokens, error)
+	Logout(ctx context.Context, sessionID string) error
+	LogoutAll(ctx context.Context, userID int64) error
+	LogoutOthers(ctx context.Context, userID int64, sessionID string) error
+	ListSessions(ctx context.Context, userID int64) ([]*entities.Session, error)
+	RevokeSession(ctx context.Context, userID int64, sessionID string) error
+	CreatePersonalAccessToken(ctx context.Context, token *entities.PersonalAccessToken) (*entities.PersonalAccessToken, string, error)
+	ListPersonalAccessTokens(ctx context.Context, userID int64) ([]*entities.PersonalAccessToken, error)
+	RevokePersonalAccessToken(ctx context.Context, userID int64, tokenID int64) error
+	RevokeAllPersonalAccessTokens(ctx context.Context, userID int64) error
 }
 
 type ITablesService interface {
-	CreateTable(ctx context.Context, table *entities.Table) (*entities.Table, error)
-	ImportTable(ctx context.Context, name string, databaseID int64, columns []string, data [][]*string) (*entities.Table, error)
-	DeleteTable(ctx context.Context, id string) error
-	RestoreTable(ctx context.Context, id string) error
+	// CreateTable, ImportTable, CopyTable, DeleteTable and RestoreTable log the change as made by the user.
+	CreateTable(ctx context.Context, userID int64, table *entities.Table) (*entities.Table, error)
+	// ImportTable creates a table of text columns, except for the multiSelectColumns named in the header,
+	// which become multi-select columns with the options found in their cells.
+	ImportTable(
+		ctx context.Context,
+		userID int64,
+		name string,
+		databaseID int64,
+		columns []string,
+		data [][]*string,
+		multiSelectColumns []string,
+	) (*entities.Table, error)
+	DeleteTable(ctx context.Context, userID int64, id string) error
+	RestoreTable(ctx context.Context, userID int64, id string) error
+	// MoveTable moves the table to the database, unless it links or is linked by other tables.
+	// Role overrides and the policies of users and custom roles belong to the source database and are dropped.
+	MoveTable(ctx context.Context, id string, databaseID int64) (*entities.Table, error)
+	// CopyTable creates a table in the database with the columns of the source one under new IDs.
+	// Access policies are not copied, as they refer to the members and roles of the source database.
+	// Tables linking other tables are only copied within their database.
+	CopyTable(ctx context.Context, userID int64, id string, databaseID int64, name string, withData, withChangelog bool) (*entities.Table, error)
 	AddColumnToTable(ctx context.Context, column *entities.TableColumn, tableID string) (*entities.Table, error)
 	EditTableColumn(ctx context.Context, column *entities.TableColumn, tableID string) (*entities.Table, bool, error)
 	DeleteColumn(ctx context.Context, columnID string, tableID string) (*entities.Table, error)
@@ -35,28 +79,134 @@ type ITablesService interface {
 	ListByDatabaseID(ctx context.Context, databaseID int64) ([]*entities.Table, error)
 	ListIDsByDatabaseID(ctx context.Context, databaseID int64) ([]string, error)
 	ListByDatabaseIDs(ctx context.Context, databaseIDs []int64) ([]*entities.Table, error)
+	// AddRow adds the row to the table view, failing with ErrorRowOutsidePolicies when the row
+	// would not pass the row policies of the view.
 	AddRow(ctx context.Context, userID int64, table *entities.Table, data map[string]*string, sortIndex *int64) (entities.TableRow, error)
 	DeleteRow(ctx context.Context, tableID string, rowID int64) (entities.TableRow, error)
 	RestoreRow(ctx context.Context, tableID string, rowID int64) error
 	MoveRow(ctx context.Context, tableID string, rowID int64, sortIndex int64) error
-	SetCellValue(ctx context.Context, userID int64, tableID string, rowID int64, columnID string, value *string) error
+	// SetCellValue sets the cell of a row in the table view, failing with ErrorRowOutsidePolicies
+	// when the row would no longer pass the row policies of the view.
+	SetCellValue(ctx context.Context, userID int64, table *entities.Table, rowID int64, columnID string, value *string) error
 	ReadTable(ctx context.Context, table *entities.Table, params entities.ReadTableParams) ([]entities.TableRow, error)
 	GetTotalRows(ctx context.Context, table *entities.Table, params entities.ReadTableParams) (int64, error)
-	ExportTable(ctx context.Context, table *entities.Table) (*excelize.File, error)
+	// IsRowAccessible reports whether the row passes the row policies of the table view.
+	IsRowAccessible(ctx context.Context, table *entities.Table, rowID int64) (bool, error)
+	// FilterAccessibleRowIDs returns the IDs of the rows, deleted or not, passing the row policies of the table view.
+	FilterAccessibleRowIDs(ctx context.Context, table *entities.Table, ids []int64) ([]int64, error)
+	// SetRowPolicies replaces the row policies of the table.
+	SetRowPolicies(ctx context.Context, tableID string, policies []*entities.RowPolicy) (*entities.Table, error)
+	// ResolveReferences replaces the link, lookup and rollup cells of the rows read from the table view
+	// with the values of the linked rows, see entities.LinkedTableView.
+	ResolveReferences(ctx context.Context, table *entities.Table, rows []entities.TableRow, linkedView entities.LinkedTableView) error
+	// ValidateLinkedRows reports whether every row the link cell value refers to exists.
+	ValidateLinkedRows(ctx context.Context, table *entities.Table, column *entities.TableColumn, value *string) (bool, error)
+	// ListLinkingTableIDs lists the other tables of the database with link columns to the table.
+	ListLinkingTableIDs(ctx context.Context, table *entities.Table) ([]string, error)
+	ExportTable(ctx context.Context, table *entities.Table, linkedView entities.LinkedTableView) (*excelize.File, error)
 	ValidateColumnValues(ctx context.Context, tableID string, column *entities.TableColumn) ([]*string, error)
 	LockTable(tableID string) func()
 	ReadLockTable(tableID string) func()
 }
 
 type IDatabasesService interface {
-	AddDatabase(ctx context.Context, userID int64, name string) (*entities.Database, error)
+	AddDatabase(ctx context.Context, userID int64, name string, organizationID *int64) (*entities.Database, error)
 	UpsertUsersDatabase(ctx context.Context, usersDatabase *entities.UsersDatabase) (*entities.UsersDatabase, error)
-	DeleteUsersDatabaseRelation(ctx context.Context, userID, databaseID int64) error
+	// SetUsersRole grants the role to the user and logs the change as made by the actor.
+	SetUsersRole(ctx context.Context, actorID int64, usersDatabase *entities.UsersDatabase) (*entities.UsersDatabase, error)
+	// DeleteUsersDatabaseRelation removes the user from the database and logs the change as made by the actor.
+	DeleteUsersDatabaseRelation(ctx context.Context, actorID, userID, databaseID int64) error
+	// GetUsersDatabases lists the databases the user is a member of, or administers through their organization.
 	GetUsersDatabases(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error)
 	GetDatabasesUsers(ctx context.Context, databaseID int64) ([]*entities.DatabasesUser, error)
 	GetDatabasesUsersIDs(ctx context.Context, databaseID int64) ([]int64, error)
 	CheckUserRole(ctx context.Context, userID, databaseID int64, requiredRole entities.Role) (bool, error)
 	GetUsersDatabaseRole(ctx context.Context, userID, databaseID int64) (entities.Role, error)
+	// GetGrantedRole returns the role the member was granted, empty for non-members.
+	// Unlike GetUsersDatabaseRole it ignores the organization admins and the token scope.
+	GetGrantedRole(ctx context.Context, userID, databaseID int64) (entities.Role, error)
+	// ListDatabasesUserCannotLeave lists the databases, deleted ones included, the user owns or is the only admin left of.
+	ListDatabasesUserCannotLeave(ctx context.Context, userID int64) ([]*entities.Database, error)
+	// DeleteUsersMemberships removes the user from every database, deleted ones included, and returns
+	// the removed memberships. It fails with ErrorCannotLeaveDatabases when the user cannot leave some of them.
+	DeleteUsersMemberships(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error)
+	TransferOwnership(ctx context.Context, actorID, databaseID, newOwnerID int64) error
+	GetDatabaseByID(ctx context.Context, id int64) (*entities.Database, error)
+	RenameDatabase(ctx context.Context, actorID, id int64, name string) (*entities.Database, error)
+	DeleteDatabase(ctx context.Context, id int64) error
+	RestoreDatabase(ctx context.Context, id int64) error
+	// GetUsersDeletedDatabases lists deleted databases the user can restore.
+	GetUsersDeletedDatabases(ctx context.Context, userID int64) ([]*entities.UsersDatabase, error)
+	// CheckUserRoleWithDeleted is CheckUserRole that also works for deleted databases.
+	CheckUserRoleWithDeleted(ctx context.Context, userID, databaseID int64, requiredRole entities.Role) (bool, error)
+	CheckUserPermission(ctx context.Context, userID, databaseID int64, permission entities.Permission) (bool, error)
+	GetUsersDatabasePermissions(ctx context.Context, userID, databaseID int64) (entities.Permissions, error)
+	// CheckUserTablePermission is CheckUserPermission that takes the table role overrides into account.
+	CheckUserTablePermission(ctx context.Context, userID int64, table *entities.Table, permission entities.Permission) (bool, error)
+	// GetUsersTableRole returns the role the table is shown with, see entities.Table.ForViewer.
+	GetUsersTableRole(ctx context.Context, userID int64, table *entities.Table) (entities.Role, error)
+	GetUsersTablePermissions(ctx context.Context, userID int64, table *entities.Table) (entities.Role, entities.Permissions, error)
+	// FilterReadableTables drops the tables the user cannot read and hides the columns
+	// the user must not see in the rest, see entities.Table.ForViewer.
+	FilterReadableTables(ctx context.Context, userID int64, tables []*entities.Table) ([]*entities.Table, error)
+	SetUsersTableRole(ctx context.Context, userID int64, table *entities.Table, role entities.Role) (*entities.UsersTable, error)
+	DeleteUsersTableRole(ctx context.Context, userID int64, tableID string) error
+	GetTablesUsers(ctx context.Context, tableID string) ([]*entities.TablesUser, error)
+	// GetRolePermissions resolves a built-in or custom role of the database, unknown roles have no permissions.
+	GetRolePermissions(ctx context.Context, databaseID int64, role entities.Role) (entities.Permissions, error)
+	// CanGrantRole reports whether the user may give the role to others: only admins grant admin,
+	// other members need to manage members and to have every permission of the role.
+	CanGrantRole(ctx context.Context, userID, databaseID int64, role entities.Role) (bool, error)
+	// CanManageMember reports whether the user may change or remove the member.
+	CanManageMember(ctx context.Context, userID, databaseID, memberID int64) (bool, error)
+	ListDatabaseRoles(ctx context.Context, databaseID int64) ([]*entities.DatabaseRole, error)
+	CreateRole(ctx context.Context, role *entities.DatabaseRole) (*entities.DatabaseRole, error)
+	UpdateRolePermissions(ctx context.Context, databaseID int64, name entities.Role, permissions entities.Permissions) (*entities.DatabaseRole, error)
+	DeleteRole(ctx context.Context, databaseID int64, name entities.Role) error
+}
+
+type IOrganizationsService interface {
+	// CreateOrganization creates the organization with the user as its admin.
+	CreateOrganization(ctx context.Context, userID int64, name string) (*entities.Organization, error)
+	GetOrganizationByID(ctx context.Context, id int64) (*entities.Organization, error)
+	GetUsersOrganizations(ctx context.Context, userID int64) ([]*entities.UsersOrganization, error)
+	GetOrganizationsUsers(ctx context.Context, organizationID int64) ([]*entities.OrganizationsUser, error)
+	// GetUsersOrganizationRole returns an empty role for users outside of the organization.
+	GetUsersOrganizationRole(ctx context.Context, userID, organizationID int64) (entities.OrganizationRole, error)
+	CheckUserRole(ctx context.Context, userID, organizationID int64, requiredRole entities.OrganizationRole) (bool, error)
+	SetUsersRole(ctx context.Context, organizationID, userID int64, role entities.OrganizationRole) error
+	DeleteUser(ctx context.Context, organizationID, userID int64) error
+	// DeleteUsersMemberships removes the user from every organization. It fails with
+	// ErrorCannotLeaveOrganizations when the user is the only admin of some of them.
+	DeleteUsersMemberships(ctx context.Context, userID int64) error
+	GetOrganizationDatabases(ctx context.Context, organizationID int64) ([]*entities.Database, error)
+	AttachDatabase(ctx context.Context, organizationID, databaseID int64) error
+	// DetachDatabase refuses to detach databases which only the organization admins could manage.
+	DetachDatabase(ctx context.Context, organizationID, databaseID int64) error
+	// GrantDefaultRole makes the organization members who are not members of the database yet
+	// members with the role, and returns their IDs. The grants are logged as made by the actor.
+	GrantDefaultRole(ctx context.Context, actorID, organizationID, databaseID int64, role entities.Role) ([]int64, error)
+}
+
+type IInvitationsService interface {
+	Invite(ctx context.Context, inviterID int64, databaseID int64, email string, role entities.Role) (*entities.DatabaseInvitation, error)
+	ListDatabaseInvitations(ctx context.Context, databaseID int64) ([]*entities.DatabaseInvitation, error)
+	RevokeInvitation(ctx context.Context, databaseID int64, invitationID int64) (*entities.DatabaseInvitation, error)
+	ListUserInvitations(ctx context.Context, userID int64) ([]*entities.DatabaseInvitation, error)
+	AcceptInvitation(ctx context.Context, user *entities.User, invitationID int64) (*entities.DatabaseInvitation, error)
+	DeclineInvitation(ctx context.Context, user *entities.User, invitationID int64) (*entities.DatabaseInvitation, error)
+	// LinkInvitations attaches invitations sent to the user's email before they registered, and once
+	// the email is verified converts those invitations into memberships, returning the converted ones.
+	LinkInvitations(ctx context.Context, user *entities.User) ([]*entities.DatabaseInvitation, error)
+}
+
+type IShareLinksService interface {
+	// CreateLink stores the link and returns it with its token, which is not retrievable later.
+	CreateLink(ctx context.Context, link *entities.TableShareLink, password *string) (*entities.TableShareLink, string, error)
+	ListTableLinks(ctx context.Context, tableID string) ([]*entities.TableShareLink, error)
+	RevokeLink(ctx context.Context, tableID string, linkID int64) error
+	// OpenLink checks the token and the password and returns the link with the table as shown through it.
+	OpenLink(ctx context.Context, token string, password *string) (*entities.TableShareLink, *entities.Table, error)
 }
 
 type IChangelogService interface {
@@ -71,6 +221,12 @@ type IChangelogService interface {
 		ctx context.Context,
 		tableID string,
 	) ([]*entities.ChangelogItemWithUserInfo, error)
+	ListChangelogForDatabase(
+		ctx context.Context,
+		databaseID int64,
+	) ([]*entities.ChangelogItemWithUserInfo, error)
+	// CopyTableChangelog writes the changelog of the source table again for its copy.
+	CopyTableChangelog(ctx context.Context, sourceTableID, targetTableID string, columnIDs map[string]string) error
 }
 
 type IFileService interface {
@@ -79,3 +235,7 @@ type IFileService interface {
 	ReadCSV(r *csv.Reader) ([]string, [][]*string, error)
 	CreateExcel(table *entities.Table, data []entities.TableRow) (f *excelize.File, err error)
 }
+
+type IMailer interface {
+	Send(ctx context.Context, message *entities.MailMessage) error
+}
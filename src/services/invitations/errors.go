@@ -0,0 +1,47 @@
+package invitations
+
+import "errors"
+
+type ErrorInvitationNotFound struct{}
+
+func (e ErrorInvitationNotFound) Error() string {
+	return "Invitation not found"
+}
+
+func IsErrInvitationNotFound(err error) bool {
+	target := ErrorInvitationNotFound{}
+	return errors.As(err, &target)
+}
+
+type ErrorAlreadyMember struct{}
+
+func (e ErrorAlreadyMember) Error() string {
+	return "User already has access to the database"
+}
+
+func IsErrAlreadyMember(err error) bool {
+	target := ErrorAlreadyMember{}
+	return errors.As(err, &target)
+}
+
+type ErrorEmailNotVerified struct{}
+
+func (e ErrorEmailNotVerified) Error() string {
+	return "Email must be verified to accept invitations"
+}
+
+func IsErrEmailNotVerified(err error) bool {
+	target := ErrorEmailNotVerified{}
+	return errors.As(err, &target)
+}
+
+type ErrorInviterCannotGrantRole struct{}
+
+func (e ErrorInviterCannotGrantRole) Error() string {
+	return "The inviter can no longer grant the role of the invitation"
+}
+
+func IsErrInviterCannotGrantRole(err error) bool {
+	target := ErrorInviterCannotGrantRole{}
+	return errors.As(err, &target)
+}
@@ -0,0 +1,317 @@
+package invitations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/domains/repositories"
+	"backend/src/modules/sql_executor"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"backend/src/services/users"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const invitationExpirationTime = 7 * 24 * time.Hour
+
+type service struct {
+	executor         sql_executor.ISQLExecutor
+	repo             repositories.IDatabaseInvitationsRepository
+	usersService     services.IUsersService
+	databasesService services.IDatabasesService
+	changelogService services.IChangelogService
+	mailer           services.IMailer
+	appURL           string
+}
+
+func NewService(
+	executor sql_executor.ISQLExecutor,
+	repo repositories.IDatabaseInvitationsRepository,
+	usersService services.IUsersService,
+	databasesService services.IDatabasesService,
+	changelogService services.IChangelogService,
+	mailer services.IMailer,
+) services.IInvitationsService {
+	return &service{
+		executor:         executor,
+		repo:             repo,
+		usersService:     usersService,
+		databasesService: databasesService,
+		changelogService: changelogService,
+		mailer:           mailer,
+		appURL:           strings.TrimRight(os.Getenv("APP_URL"), "/"),
+	}
+}
+
+func (s *service) Invite(
+	ctx context.Context,
+	inviterID int64,
+	databaseID int64,
+	email string,
+	role entities.Role,
+) (*entities.DatabaseInvitation, error) {
+	invitation := &entities.DatabaseInvitation{
+		DatabaseID: databaseID,
+		Email:      strings.TrimSpace(email),
+		Role:       role,
+		InvitedBy:  inviterID,
+		ExpiresAt:  time.Now().Add(invitationExpirationTime),
+	}
+
+	invitee, err := s.usersService.FindUserByEmail(ctx, invitation.Email)
+	switch {
+	case err == nil:
+		currentRole, err := s.databasesService.GetUsersDatabaseRole(ctx, invitee.ID, databaseID)
+		if err != nil {
+			return nil, err
+		}
+		if currentRole != "" {
+			return nil, ErrorAlreadyMember{}
+		}
+		invitation.UserID = &invitee.ID
+	case !users.IsErrUserNotFound(err):
+		return nil, err
+	}
+
+	invitation, err = s.repo.UpsertInvitation(ctx, invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sendInvitationEmail(ctx, invitation); err != nil {
+		log.Printf("Error sending invitation %d email: %v", invitation.ID, err)
+	}
+
+	return invitation, nil
+}
+
+func (s *service) sendInvitationEmail(ctx context.Context, invitation *entities.DatabaseInvitation) error {
+	database, err := s.databasesService.GetDatabaseByID(ctx, invitation.DatabaseID)
+	if err != nil {
+		return err
+	}
+	inviter, err := s.usersService.FindUserByID(ctx, invitation.InvitedBy)
+	if err != nil {
+		return err
+	}
+
+	link := s.appURL + "/invitations"
+	if invitation.UserID == nil {
+		link = s.appURL + "/register"
+	}
+
+	return s.mailer.Send(ctx, &entities.MailMessage{
+		To:      invitation.Email,
+		Subject: "Приглашение в SimpleTable",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n%s приглашает вас в базу данных «%s» в SimpleTable.\nЧтобы принять приглашение, перейдите по ссылке:\n%s\n\nПриглашение действительно до %s.",
+			inviter.Name, database.Name, link, invitation.ExpiresAt.Format("02.01.2006"),
+		),
+	})
+}
+
+func (s *service) ListDatabaseInvitations(ctx context.Context, databaseID int64) ([]*entities.DatabaseInvitation, error) {
+	return s.repo.ListPendingDatabaseInvitations(ctx, databaseID)
+}
+
+func (s *service) RevokeInvitation(ctx context.Context, databaseID int64, invitationID int64) (*entities.DatabaseInvitation, error) {
+	invitation, err := s.getPendingInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.DatabaseID != databaseID {
+		return nil, ErrorInvitationNotFound{}
+	}
+
+	if err := s.respond(ctx, invitation, entities.InvitationStatusRevoked); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (s *service) ListUserInvitations(ctx context.Context, userID int64) ([]*entities.DatabaseInvitation, error) {
+	return s.repo.ListPendingUserInvitations(ctx, userID)
+}
+
+func (s *service) AcceptInvitation(ctx context.Context, user *entities.User, invitationID int64) (*entities.DatabaseInvitation, error) {
+	invitation, err := s.getUsersPendingInvitation(ctx, user, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	// Otherwise anyone could register with somebody else's address and take their invitations.
+	if user.EmailVerifiedAt == nil {
+		return nil, ErrorEmailNotVerified{}
+	}
+
+	if err := s.accept(ctx, user, invitation); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// accept consumes the invitation and makes the user a member together, so that a failed
+// membership change leaves the invitation pending. The granted role is logged as given by the inviter,
+// who must still be able to grant it. Members keep their role unless the invited one raises it.
+func (s *service) accept(ctx context.Context, user *entities.User, invitation *entities.DatabaseInvitation) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.respond(ctx, invitation, entities.InvitationStatusAccepted); err != nil {
+			return err
+		}
+
+		granted, err := s.databasesService.CanGrantRole(ctx, invitation.InvitedBy, invitation.DatabaseID, invitation.Role)
+		if err != nil && !databases.IsErrRoleNotFound(err) {
+			return err
+		}
+		if !granted {
+			return ErrorInviterCannotGrantRole{}
+		}
+
+		oldRole, err := s.databasesService.GetGrantedRole(ctx, user.ID, invitation.DatabaseID)
+		if err != nil {
+			return err
+		}
+		raises, err := s.raisesRole(ctx, invitation.DatabaseID, oldRole, invitation.Role)
+		if err != nil || !raises {
+			return err
+		}
+
+		_, err = s.databasesService.UpsertUsersDatabase(ctx, &entities.UsersDatabase{
+			UserID:     user.ID,
+			DatabaseID: invitation.DatabaseID,
+			Role:       invitation.Role,
+		})
+		if err != nil {
+			return err
+		}
+
+		memberChange := entities.NewMemberChange(user.ID, oldRole, invitation.Role)
+		return s.changelogService.WriteChangelog(ctx, memberChange.ToChangelogItem(invitation.InvitedBy, invitation.DatabaseID))
+	})
+}
+
+// raisesRole reports whether the invited role gives the member every permission of the current one and more.
+func (s *service) raisesRole(ctx context.Context, databaseID int64, current, invited entities.Role) (bool, error) {
+	switch {
+	case current == "":
+		return true, nil
+	case current == invited || current == entities.RoleAdmin:
+		return false, nil
+	case invited == entities.RoleAdmin:
+		return true, nil
+	}
+
+	currentPermissions, err := s.databasesService.GetRolePermissions(ctx, databaseID, current)
+	if err != nil {
+		return false, err
+	}
+	invitedPermissions, err := s.databasesService.GetRolePermissions(ctx, databaseID, invited)
+	if err != nil {
+		return false, err
+	}
+	return invitedPermissions.Contains(currentPermissions), nil
+}
+
+func (s *service) DeclineInvitation(ctx context.Context, user *entities.User, invitationID int64) (*entities.DatabaseInvitation, error) {
+	invitation, err := s.getUsersPendingInvitation(ctx, user, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.respond(ctx, invitation, entities.InvitationStatusDeclined); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (s *service) LinkInvitations(ctx context.Context, user *entities.User) ([]*entities.DatabaseInvitation, error) {
+	if _, err := s.repo.LinkInvitationsToUser(ctx, user.ID, user.Email); err != nil {
+		return nil, err
+	}
+
+	// The caller's copy of the user may predate the verification of the email.
+	user, err := s.usersService.FindUserByID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user.EmailVerifiedAt == nil {
+		return nil, nil
+	}
+
+	pendingInvitations, err := s.repo.ListPendingUserInvitations(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted := make([]*entities.DatabaseInvitation, 0, len(pendingInvitations))
+	for _, invitation := range pendingInvitations {
+		// Invitations sent once the account existed are left for the user to accept.
+		if !invitation.CreatedAt.Before(user.CreatedAt) {
+			continue
+		}
+		// The invitation may have been answered, or its database trashed, meanwhile.
+		if _, err := s.getPendingInvitation(ctx, invitation.ID); err != nil {
+			if IsErrInvitationNotFound(err) {
+				continue
+			}
+			return accepted, err
+		}
+
+		if err := s.accept(ctx, user, invitation); err != nil {
+			if IsErrInvitationNotFound(err) || IsErrInviterCannotGrantRole(err) {
+				continue
+			}
+			return accepted, err
+		}
+		accepted = append(accepted, invitation)
+	}
+	return accepted, nil
+}
+
+func (s *service) getUsersPendingInvitation(ctx context.Context, user *entities.User, invitationID int64) (*entities.DatabaseInvitation, error) {
+	invitation, err := s.getPendingInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.UserID == nil || *invitation.UserID != user.ID {
+		return nil, ErrorInvitationNotFound{}
+	}
+	return invitation, nil
+}
+
+func (s *service) getPendingInvitation(ctx context.Context, invitationID int64) (*entities.DatabaseInvitation, error) {
+	invitation, err := s.repo.GetInvitationByID(ctx, invitationID)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, ErrorInvitationNotFound{}
+		}
+		return nil, err
+	}
+	if !invitation.IsPending() {
+		return nil, ErrorInvitationNotFound{}
+	}
+
+	// Invitations to trashed databases cannot be answered until the database is restored.
+	if _, err := s.databasesService.GetDatabaseByID(ctx, invitation.DatabaseID); err != nil {
+		if databases.IsErrDatabaseNotFound(err) {
+			return nil, ErrorInvitationNotFound{}
+		}
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (s *service) respond(ctx context.Context, invitation *entities.DatabaseInvitation, status entities.InvitationStatus) error {
+	responded, err := s.repo.RespondToInvitation(ctx, invitation.ID, status)
+	if err != nil {
+		return err
+	}
+	// Someone else has already responded to it concurrently.
+	if !responded {
+		return ErrorInvitationNotFound{}
+	}
+	invitation.Status = status
+	return nil
+}
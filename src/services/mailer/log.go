@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/services"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// logMailer is meant for local development: messages are written to the log,
+// or saved as .eml files when a directory is configured.
+type logMailer struct {
+	dir string
+}
+
+func NewLogMailer(dir string) services.IMailer {
+	return &logMailer{
+		dir: dir,
+	}
+}
+
+func (m *logMailer) Send(ctx context.Context, message *entities.MailMessage) error {
+	raw := buildMessage("simple-table@localhost", message)
+	if m.dir == "" {
+		log.Printf("Mail to %s:\n%s", message.To, raw)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), unsafeFilenameChars.ReplaceAllString(message.To, "_"))
+	return os.WriteFile(filepath.Join(m.dir, name), raw, 0o644)
+}
@@ -0,0 +1,80 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"errors"
+	"strings"
+)
+
+type ErrorOrganizationNotFound struct{}
+
+func (e ErrorOrganizationNotFound) Error() string {
+	return "Organization not found"
+}
+
+func IsErrOrganizationNotFound(err error) bool {
+	target := ErrorOrganizationNotFound{}
+	return errors.As(err, &target)
+}
+
+type ErrorLastAdmin struct{}
+
+func (e ErrorLastAdmin) Error() string {
+	return "Organization must keep at least one admin"
+}
+
+func IsErrLastAdmin(err error) bool {
+	target := ErrorLastAdmin{}
+	return errors.As(err, &target)
+}
+
+type ErrorDatabaseNotInOrganization struct{}
+
+func (e ErrorDatabaseNotInOrganization) Error() string {
+	return "Database does not belong to the organization"
+}
+
+func IsErrDatabaseNotInOrganization(err error) bool {
+	target := ErrorDatabaseNotInOrganization{}
+	return errors.As(err, &target)
+}
+
+type ErrorDatabaseInOtherOrganization struct{}
+
+func (e ErrorDatabaseInOtherOrganization) Error() string {
+	return "Database already belongs to an organization, detach it first"
+}
+
+func IsErrDatabaseInOtherOrganization(err error) bool {
+	target := ErrorDatabaseInOtherOrganization{}
+	return errors.As(err, &target)
+}
+
+type ErrorDatabaseWithoutAdmins struct{}
+
+func (e ErrorDatabaseWithoutAdmins) Error() string {
+	return "Database has no admins of its own, make a member an admin before detaching it"
+}
+
+func IsErrDatabaseWithoutAdmins(err error) bool {
+	target := ErrorDatabaseWithoutAdmins{}
+	return errors.As(err, &target)
+}
+
+type ErrorCannotLeaveOrganizations struct {
+	Organizations []*entities.UsersOrganization
+}
+
+func (e ErrorCannotLeaveOrganizations) Error() string {
+	names := make([]string, 0, len(e.Organizations))
+	for _, organization := range e.Organizations {
+		names = append(names, organization.Name)
+	}
+	return "User is the only admin of organizations: " + strings.Join(names, ", ")
+}
+
+func IsErrCannotLeaveOrganizations(err error) (ErrorCannotLeaveOrganizations, bool) {
+	target := ErrorCannotLeaveOrganizations{}
+	ok := errors.As(err, &target)
+	return target, ok
+}
@@ -0,0 +1,285 @@
+package organizations
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/domains/repositories"
+	"backend/src/modules/sql_executor"
+	"backend/src/services"
+	"backend/src/services/databases"
+	"context"
+)
+
+type service struct {
+	executor         sql_executor.ISQLExecutor
+	repo             repositories.IOrganizationsRepository
+	databasesRepo    repositories.IDatabasesRepository
+	changelogService services.IChangelogService
+}
+
+func NewService(
+	executor sql_executor.ISQLExecutor,
+	repo repositories.IOrganizationsRepository,
+	databasesRepo repositories.IDatabasesRepository,
+	changelogService services.IChangelogService,
+) services.IOrganizationsService {
+	return &service{
+		executor:         executor,
+		repo:             repo,
+		databasesRepo:    databasesRepo,
+		changelogService: changelogService,
+	}
+}
+
+func (s *service) CreateOrganization(ctx context.Context, userID int64, name string) (*entities.Organization, error) {
+	var createdOrganization *entities.Organization
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdOrganization, err = s.repo.AddOrganization(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.repo.UpsertUsersOrganization(ctx, &entities.UsersOrganization{
+			UserID:         userID,
+			OrganizationID: createdOrganization.ID,
+			Role:           entities.OrganizationRoleAdmin,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdOrganization, nil
+}
+
+func (s *service) GetOrganizationByID(ctx context.Context, id int64) (*entities.Organization, error) {
+	organization, err := s.repo.GetOrganizationByID(ctx, id)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, ErrorOrganizationNotFound{}
+		}
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (s *service) GetUsersOrganizations(ctx context.Context, userID int64) ([]*entities.UsersOrganization, error) {
+	return s.repo.GetUsersOrganizations(ctx, userID)
+}
+
+func (s *service) GetOrganizationsUsers(ctx context.Context, organizationID int64) ([]*entities.OrganizationsUser, error) {
+	return s.repo.GetOrganizationsUsers(ctx, organizationID)
+}
+
+func (s *service) GetUsersOrganizationRole(ctx context.Context, userID, organizationID int64) (entities.OrganizationRole, error) {
+	role, err := s.repo.GetUsersOrganizationRole(ctx, userID, organizationID)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return role, nil
+}
+
+func (s *service) CheckUserRole(
+	ctx context.Context,
+	userID, organizationID int64,
+	requiredRole entities.OrganizationRole,
+) (bool, error) {
+	role, err := s.GetUsersOrganizationRole(ctx, userID, organizationID)
+	if err != nil {
+		return false, err
+	}
+
+	return role != "" && role.Authorize(requiredRole), nil
+}
+
+func (s *service) SetUsersRole(ctx context.Context, organizationID, userID int64, role entities.OrganizationRole) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if role != entities.OrganizationRoleAdmin {
+			if err := s.checkAdminCanLeave(ctx, userID, organizationID); err != nil {
+				return err
+			}
+		}
+
+		_, err := s.repo.UpsertUsersOrganization(ctx, &entities.UsersOrganization{
+			UserID:         userID,
+			OrganizationID: organizationID,
+			Role:           role,
+		})
+		return err
+	})
+}
+
+func (s *service) DeleteUser(ctx context.Context, organizationID, userID int64) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkAdminCanLeave(ctx, userID, organizationID); err != nil {
+			return err
+		}
+
+		return s.repo.DeleteUsersOrganization(ctx, userID, organizationID)
+	})
+}
+
+func (s *service) DeleteUsersMemberships(ctx context.Context, userID int64) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		usersOrganizations, err := s.repo.GetUsersOrganizations(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		blockingOrganizations := make([]*entities.UsersOrganization, 0)
+		for _, usersOrganization := range usersOrganizations {
+			err := s.checkAdminCanLeave(ctx, userID, usersOrganization.OrganizationID)
+			if IsErrLastAdmin(err) {
+				blockingOrganizations = append(blockingOrganizations, usersOrganization)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if len(blockingOrganizations) > 0 {
+			return ErrorCannotLeaveOrganizations{Organizations: blockingOrganizations}
+		}
+
+		for _, usersOrganization := range usersOrganizations {
+			if err := s.repo.DeleteUsersOrganization(ctx, userID, usersOrganization.OrganizationID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// checkAdminCanLeave makes sure the organization keeps an admin once the user loses the role.
+// It must run inside a transaction, as it locks the organization row.
+func (s *service) checkAdminCanLeave(ctx context.Context, userID, organizationID int64) error {
+	if _, err := s.lockOrganization(ctx, organizationID); err != nil {
+		return err
+	}
+
+	role, err := s.GetUsersOrganizationRole(ctx, userID, organizationID)
+	if err != nil {
+		return err
+	}
+	if role != entities.OrganizationRoleAdmin {
+		return nil
+	}
+
+	otherAdmins, err := s.repo.CountOtherAdmins(ctx, organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if otherAdmins == 0 {
+		return ErrorLastAdmin{}
+	}
+	return nil
+}
+
+func (s *service) lockOrganization(ctx context.Context, organizationID int64) (*entities.Organization, error) {
+	organization, err := s.repo.LockOrganization(ctx, organizationID)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, ErrorOrganizationNotFound{}
+		}
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (s *service) GetOrganizationDatabases(ctx context.Context, organizationID int64) ([]*entities.Database, error) {
+	return s.repo.GetOrganizationDatabases(ctx, organizationID)
+}
+
+func (s *service) AttachDatabase(ctx context.Context, organizationID, databaseID int64) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		database, err := s.lockDatabase(ctx, databaseID)
+		if err != nil {
+			return err
+		}
+		if database.OrganizationID != nil {
+			if *database.OrganizationID == organizationID {
+				return nil
+			}
+			return ErrorDatabaseInOtherOrganization{}
+		}
+
+		return s.repo.SetDatabaseOrganization(ctx, databaseID, &organizationID)
+	})
+}
+
+func (s *service) DetachDatabase(ctx context.Context, organizationID, databaseID int64) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.getOrganizationDatabase(ctx, organizationID, databaseID); err != nil {
+			return err
+		}
+
+		// without the organization admins the database would be left with nobody to manage it
+		admins, err := s.databasesRepo.CountOtherAdmins(ctx, databaseID, 0)
+		if err != nil {
+			return err
+		}
+		if admins == 0 {
+			return ErrorDatabaseWithoutAdmins{}
+		}
+
+		return s.repo.SetDatabaseOrganization(ctx, databaseID, nil)
+	})
+}
+
+func (s *service) GrantDefaultRole(
+	ctx context.Context,
+	actorID, organizationID, databaseID int64,
+	role entities.Role,
+) ([]int64, error) {
+	var grantedUserIDs []int64
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.getOrganizationDatabase(ctx, organizationID, databaseID); err != nil {
+			return err
+		}
+
+		var err error
+		grantedUserIDs, err = s.repo.GrantMembersDatabaseRole(ctx, organizationID, databaseID, role)
+		if err != nil || len(grantedUserIDs) == 0 {
+			return err
+		}
+
+		changelog := make([]*entities.ChangelogItem, 0, len(grantedUserIDs))
+		for _, grantedUserID := range grantedUserIDs {
+			memberChange := entities.NewMemberChange(grantedUserID, "", role)
+			changelog = append(changelog, memberChange.ToChangelogItem(actorID, databaseID))
+		}
+		return s.changelogService.WriteChangelog(ctx, changelog...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return grantedUserIDs, nil
+}
+
+// getOrganizationDatabase locks the database, making sure it belongs to the organization.
+func (s *service) getOrganizationDatabase(ctx context.Context, organizationID, databaseID int64) (*entities.Database, error) {
+	database, err := s.lockDatabase(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	if database.OrganizationID == nil || *database.OrganizationID != organizationID {
+		return nil, ErrorDatabaseNotInOrganization{}
+	}
+	return database, nil
+}
+
+func (s *service) lockDatabase(ctx context.Context, databaseID int64) (*entities.Database, error) {
+	database, err := s.databasesRepo.LockDatabase(ctx, databaseID)
+	if err != nil {
+		if s.databasesRepo.IsErrNoRows(err) {
+			return nil, databases.ErrorDatabaseNotFound{}
+		}
+		return nil, err
+	}
+	return database, nil
+}
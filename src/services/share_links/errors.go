@@ -0,0 +1,36 @@
+package share_links
+
+import "errors"
+
+type ErrorShareLinkNotFound struct{}
+
+func (e ErrorShareLinkNotFound) Error() string {
+	return "Share link not found"
+}
+
+func IsErrShareLinkNotFound(err error) bool {
+	target := ErrorShareLinkNotFound{}
+	return errors.As(err, &target)
+}
+
+type ErrorPasswordRequired struct{}
+
+func (e ErrorPasswordRequired) Error() string {
+	return "Share link is protected by a password"
+}
+
+func IsErrPasswordRequired(err error) bool {
+	target := ErrorPasswordRequired{}
+	return errors.As(err, &target)
+}
+
+type ErrorWrongPassword struct{}
+
+func (e ErrorWrongPassword) Error() string {
+	return "Wrong share link password"
+}
+
+func IsErrWrongPassword(err error) bool {
+	target := ErrorWrongPassword{}
+	return errors.As(err, &target)
+}
@@ -0,0 +1,131 @@
+package share_links
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/domains/repositories"
+	"backend/src/modules/password_hasher"
+	"backend/src/modules/secure_token"
+	"backend/src/services"
+	"backend/src/services/tables"
+	"context"
+)
+
+const (
+	shareLinkTokenPrefix        = "stshr_"
+	shareLinkTokenDisplayLength = len(shareLinkTokenPrefix) + 6
+)
+
+type service struct {
+	repo           repositories.ITableShareLinksRepository
+	tablesService  services.ITablesService
+	passwordHasher password_hasher.IPasswordHasher
+}
+
+func NewService(
+	repo repositories.ITableShareLinksRepository,
+	tablesService services.ITablesService,
+	passwordHasher password_hasher.IPasswordHasher,
+) services.IShareLinksService {
+	return &service{
+		repo:           repo,
+		tablesService:  tablesService,
+		passwordHasher: passwordHasher,
+	}
+}
+
+func (s *service) CreateLink(
+	ctx context.Context,
+	link *entities.TableShareLink,
+	password *string,
+) (*entities.TableShareLink, string, error) {
+	table, err := s.tablesService.GetTableByID(ctx, link.TableID, false)
+	if err != nil {
+		return nil, "", err
+	}
+	if columnIDs := link.ColumnIDs.Get(); columnIDs != nil {
+		for _, id := range *columnIDs {
+			if !table.HasColumn(id) {
+				return nil, "", tables.ErrorColumnNotFound{}
+			}
+		}
+	}
+
+	if password != nil {
+		hash, err := s.passwordHasher.Hash(*password)
+		if err != nil {
+			return nil, "", err
+		}
+		link.PasswordHash = &hash
+	}
+
+	tokenString, err := secure_token.Generate(shareLinkTokenPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	link.TokenHash = secure_token.Hash(tokenString)
+	link.TokenPrefix = tokenString[:shareLinkTokenDisplayLength]
+
+	createdLink, err := s.repo.CreateLink(ctx, link)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return createdLink, tokenString, nil
+}
+
+func (s *service) ListTableLinks(ctx context.Context, tableID string) ([]*entities.TableShareLink, error) {
+	return s.repo.ListTableLinks(ctx, tableID)
+}
+
+func (s *service) RevokeLink(ctx context.Context, tableID string, linkID int64) error {
+	revoked, err := s.repo.RevokeLink(ctx, tableID, linkID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrorShareLinkNotFound{}
+	}
+	return nil
+}
+
+func (s *service) OpenLink(
+	ctx context.Context,
+	token string,
+	password *string,
+) (*entities.TableShareLink, *entities.Table, error) {
+	link, err := s.repo.GetLinkByHash(ctx, secure_token.Hash(token))
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, nil, ErrorShareLinkNotFound{}
+		}
+		return nil, nil, err
+	}
+	if !link.IsActive() {
+		return nil, nil, ErrorShareLinkNotFound{}
+	}
+
+	if link.HasPassword() {
+		if password == nil || *password == "" {
+			return nil, nil, ErrorPasswordRequired{}
+		}
+		ok, err := s.passwordHasher.Compare(*link.PasswordHash, *password)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			return nil, nil, ErrorWrongPassword{}
+		}
+	}
+
+	table, err := s.tablesService.GetTableByID(ctx, link.TableID, false)
+	if err != nil {
+		// links of deleted tables stop working until the table is restored
+		if tables.IsErrTableNotFound(err) {
+			return nil, nil, ErrorShareLinkNotFound{}
+		}
+		return nil, nil, err
+	}
+
+	return link, link.Apply(table), nil
+}
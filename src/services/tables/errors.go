@@ -38,3 +38,78 @@ func (e *ErrorInvalidColumnValue) Error() string {
 	}
 	return fmt.Sprintf("Invalid column value `%s`", val)
 }
+
+func IsErrInvalidColumnValue(err error) bool {
+	var target *ErrorInvalidColumnValue
+	return errors.As(err, &target)
+}
+
+type ErrorSameDatabase struct{}
+
+func (e ErrorSameDatabase) Error() string {
+	return "Table is already in the database"
+}
+
+func IsErrSameDatabase(err error) bool {
+	target := ErrorSameDatabase{}
+	return errors.As(err, &target)
+}
+
+type ErrorTableHasLinks struct{}
+
+func (e ErrorTableHasLinks) Error() string {
+	return "Table is linked with other tables of its database"
+}
+
+func IsErrTableHasLinks(err error) bool {
+	target := ErrorTableHasLinks{}
+	return errors.As(err, &target)
+}
+
+type ErrorComputedRowPredicate struct{}
+
+func (e ErrorComputedRowPredicate) Error() string {
+	return "Row policies cannot filter on computed columns"
+}
+
+func IsErrComputedRowPredicate(err error) bool {
+	target := ErrorComputedRowPredicate{}
+	return errors.As(err, &target)
+}
+
+type ErrorInvalidFormula struct {
+	reason string
+}
+
+func (e ErrorInvalidFormula) Error() string {
+	return "Invalid formula: " + e.reason
+}
+
+func IsErrInvalidFormula(err error) bool {
+	target := ErrorInvalidFormula{}
+	return errors.As(err, &target)
+}
+
+type ErrorInvalidColumnReference struct {
+	reason string
+}
+
+func (e ErrorInvalidColumnReference) Error() string {
+	return "Invalid column reference: " + e.reason
+}
+
+func IsErrInvalidColumnReference(err error) bool {
+	target := ErrorInvalidColumnReference{}
+	return errors.As(err, &target)
+}
+
+type ErrorRowOutsidePolicies struct{}
+
+func (e ErrorRowOutsidePolicies) Error() string {
+	return "Row does not pass the row policies"
+}
+
+func IsErrRowOutsidePolicies(err error) bool {
+	target := ErrorRowOutsidePolicies{}
+	return errors.As(err, &target)
+}
@@ -0,0 +1,46 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"backend/src/modules/formula"
+)
+
+// parseFormula switches the column references of a formula column from names to IDs.
+func parseFormula(table *entities.Table, column *entities.TableColumn) error {
+	if column.Type != entities.ColumnTypeFormula {
+		column.Formula = nil
+		return nil
+	}
+	if column.Formula == nil {
+		return ErrorInvalidFormula{reason: "formula is empty"}
+	}
+
+	parsed, err := table.ParseFormula(column.Formula.Expression)
+	if err != nil {
+		return ErrorInvalidFormula{reason: err.Error()}
+	}
+	column.Formula = parsed
+	return nil
+}
+
+// compileFormulas checks that every formula of the table can still be computed after a schema change.
+func compileFormulas(table *entities.Table) error {
+	if err := table.CompileFormulas(); err != nil {
+		return ErrorInvalidFormula{reason: err.Error()}
+	}
+	return nil
+}
+
+// remapFormula points the references of a copied formula to the copied columns.
+func remapFormula(column *entities.TableColumn, columnIDs map[string]string) {
+	expression, err := formula.ReplaceReferences(column.Formula.Expression, func(id string) (string, error) {
+		if copied, ok := columnIDs[id]; ok {
+			return copied, nil
+		}
+		return id, nil
+	})
+	if err != nil {
+		return
+	}
+	column.Formula = &entities.ColumnFormula{Expression: expression, ResultType: column.Formula.ResultType}
+}
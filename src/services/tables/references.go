@@ -0,0 +1,332 @@
+package tables
+
+import (
+	"backend/src/domains/entities"
+	"context"
+)
+
+// validateReferences checks the configuration of link, lookup and rollup columns.
+// Linked and looked up columns cannot be references themselves, so references never chain.
+func (s *service) validateReferences(ctx context.Context, table *entities.Table, column *entities.TableColumn) error {
+	switch column.Type {
+	case entities.ColumnTypeLink:
+		if column.Link == nil {
+			return ErrorInvalidColumnReference{reason: "link column must have a linked table"}
+		}
+
+		linkedTable, err := s.getLinkedTable(ctx, table, column.Link.TableID)
+		if err != nil {
+			return err
+		}
+		if !isLinkableColumn(linkedTable.GetColumn(column.Link.DisplayColumnID)) {
+			return ErrorInvalidColumnReference{reason: "display column not found"}
+		}
+		return nil
+
+	case entities.ColumnTypeLookup, entities.ColumnTypeRollup:
+		linkColumn := table.GetColumn(column.LinkColumnID())
+		if linkColumn == nil || linkColumn.Type != entities.ColumnTypeLink || linkColumn.Link == nil {
+			return ErrorInvalidColumnReference{reason: "link column not found"}
+		}
+
+		linkedTable, err := s.getLinkedTable(ctx, table, linkColumn.Link.TableID)
+		if err != nil {
+			return err
+		}
+
+		if column.Type == entities.ColumnTypeRollup && column.Rollup.Function == entities.RollupFunctionCount {
+			return nil
+		}
+
+		linkedColumn := linkedTable.GetColumn(column.LinkedColumnID())
+		if !isLinkableColumn(linkedColumn) {
+			return ErrorInvalidColumnReference{reason: "linked column not found"}
+		}
+		if column.Type == entities.ColumnTypeRollup && column.Rollup.Function == entities.RollupFunctionSum &&
+			linkedColumn.Type != entities.ColumnTypeNumeric {
+			return ErrorInvalidColumnReference{reason: "sum needs a numeric column"}
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// getLinkedTable returns the table a link column points to, it must be in the database of the linking table.
+func (s *service) getLinkedTable(ctx context.Context, table *entities.Table, linkedTableID string) (*entities.Table, error) {
+	if linkedTableID == table.ID {
+		return table, nil
+	}
+
+	linkedTable, err := s.repo.GetTableByID(ctx, linkedTableID, false)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, ErrorInvalidColumnReference{reason: "linked table not found"}
+		}
+		return nil, err
+	}
+	if linkedTable.DatabaseID != table.DatabaseID {
+		return nil, ErrorInvalidColumnReference{reason: "linked table must be in the same database"}
+	}
+	return linkedTable, nil
+}
+
+// remapReferences points the copied columns to the copy of the table: links of the table to itself
+// go to the copy, lookups and rollups go through the copied link columns, formulas use the copied columns.
+func remapReferences(table *entities.Table, sourceID string, columnIDs map[string]string) {
+	selfLinks := make(map[string]struct{})
+	for _, col := range table.Columns {
+		if col.Type == entities.ColumnTypeLink && col.Link != nil && col.Link.TableID == sourceID {
+			col.Link = &entities.ColumnLink{
+				TableID:         table.ID,
+				DisplayColumnID: columnIDs[col.Link.DisplayColumnID],
+			}
+			selfLinks[col.ID] = struct{}{}
+		}
+	}
+
+	for _, col := range table.Columns {
+		switch {
+		case col.Type == entities.ColumnTypeFormula && col.Formula != nil:
+			remapFormula(col, columnIDs)
+		case col.Type == entities.ColumnTypeLookup && col.Lookup != nil:
+			lookup := *col.Lookup
+			lookup.LinkColumnID = columnIDs[lookup.LinkColumnID]
+			if _, ok := selfLinks[lookup.LinkColumnID]; ok {
+				lookup.ColumnID = columnIDs[lookup.ColumnID]
+			}
+			col.Lookup = &lookup
+		case col.Type == entities.ColumnTypeRollup && col.Rollup != nil:
+			rollup := *col.Rollup
+			rollup.LinkColumnID = columnIDs[rollup.LinkColumnID]
+			if _, ok := selfLinks[rollup.LinkColumnID]; ok && rollup.ColumnID != "" {
+				rollup.ColumnID = columnIDs[rollup.ColumnID]
+			}
+			col.Rollup = &rollup
+		}
+	}
+}
+
+// linksOtherTables reports whether the table has link columns to other tables, which cannot follow it to another database.
+func linksOtherTables(table *entities.Table) bool {
+	for _, col := range table.Columns {
+		if col.DeletedAt == nil && col.Type == entities.ColumnTypeLink && col.Link != nil && col.Link.TableID != table.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func isLinkableColumn(column *entities.TableColumn) bool {
+	return column != nil && !column.IsReference()
+}
+
+func (s *service) ValidateLinkedRows(ctx context.Context, table *entities.Table, column *entities.TableColumn, value *string) (bool, error) {
+	if column.Type != entities.ColumnTypeLink || value == nil {
+		return true, nil
+	}
+
+	ids, err := entities.ParseLinkedRowIDs(*value)
+	if err != nil {
+		return false, nil
+	}
+	if len(ids) == 0 {
+		return true, nil
+	}
+
+	linkedTable, err := s.getLinkedTable(ctx, table, column.Link.TableID)
+	if err != nil {
+		if IsErrInvalidColumnReference(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	rows, err := s.repo.GetRowsByIDs(ctx, linkedTable, ids)
+	if err != nil {
+		return false, err
+	}
+
+	found := make(map[int64]struct{}, len(rows))
+	for _, row := range rows {
+		found[row.GetID()] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *service) ResolveReferences(
+	ctx context.Context,
+	table *entities.Table,
+	rows []entities.TableRow,
+	linkedView entities.LinkedTableView,
+) error {
+	linkColumns := make(map[string]*entities.TableColumn)
+	for _, col := range table.Columns {
+		if col.DeletedAt == nil && col.Type == entities.ColumnTypeLink && col.Link != nil {
+			linkColumns[col.ID] = col
+		}
+	}
+
+	// linked rows are fetched once per linked table, whatever the number of columns linking it
+	linkedIDs := make(map[string][]int64)
+	for _, row := range rows {
+		for _, col := range linkColumns {
+			linkedIDs[col.Link.TableID] = append(linkedIDs[col.Link.TableID], linkedRowIDs(row, col)...)
+		}
+	}
+
+	linkedRows := make(map[string]map[int64]entities.TableRow, len(linkedIDs))
+	linkedTables := make(map[string]*entities.Table, len(linkedIDs))
+	for linkedTableID, ids := range linkedIDs {
+		view, err := s.getLinkedView(ctx, table, linkedTableID, linkedView)
+		if err != nil {
+			return err
+		}
+		if view == nil {
+			continue
+		}
+		linkedTables[linkedTableID] = view
+
+		if len(ids) == 0 {
+			linkedRows[linkedTableID] = map[int64]entities.TableRow{}
+			continue
+		}
+		found, err := s.repo.GetRowsByIDs(ctx, view, ids)
+		if err != nil {
+			return err
+		}
+		byID := make(map[int64]entities.TableRow, len(found))
+		for _, linkedRow := range found {
+			byID[linkedRow.GetID()] = linkedRow
+		}
+		linkedRows[linkedTableID] = byID
+	}
+
+	for _, row := range rows {
+		// lookups and rollups read the stored IDs, so links are replaced last
+		for _, col := range table.Columns {
+			if col.DeletedAt != nil || (col.Type != entities.ColumnTypeLookup && col.Type != entities.ColumnTypeRollup) {
+				continue
+			}
+
+			linkColumn, ok := linkColumns[col.LinkColumnID()]
+			if !ok {
+				row[col.ID] = nil
+				continue
+			}
+
+			records := resolveLinkedRecords(row, linkColumn, linkedRows[linkColumn.Link.TableID], col.LinkedColumnID())
+			if col.Type == entities.ColumnTypeLookup {
+				row[col.ID] = records
+				continue
+			}
+
+			linkedTable, ok := linkedTables[linkColumn.Link.TableID]
+			if !ok {
+				row[col.ID] = nil
+				continue
+			}
+			values := make([]*string, 0, len(records))
+			for _, record := range records {
+				values = append(values, record.Value)
+			}
+			aggregatedColumn := linkedTable.GetColumn(col.LinkedColumnID())
+			if aggregatedColumn == nil {
+				aggregatedColumn = &entities.TableColumn{}
+			}
+			row[col.ID] = col.Rollup.Aggregate(aggregatedColumn, values)
+		}
+
+		for _, col := range linkColumns {
+			row[col.ID] = resolveLinkedRecords(row, col, linkedRows[col.Link.TableID], col.Link.DisplayColumnID)
+		}
+	}
+
+	return nil
+}
+
+// getLinkedView returns the linked table through the reader's view of it, nil when the reader cannot see it.
+// Links to deleted tables or to tables moved to another database resolve to no rows.
+func (s *service) getLinkedView(
+	ctx context.Context,
+	table *entities.Table,
+	linkedTableID string,
+	linkedView entities.LinkedTableView,
+) (*entities.Table, error) {
+	linkedTable, err := s.repo.GetTableByID(ctx, linkedTableID, false)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if linkedTable.DatabaseID != table.DatabaseID {
+		return nil, nil
+	}
+	return linkedView(ctx, linkedTable)
+}
+
+// linkedRowIDs returns the IDs stored in the link cell, invalid values link no rows.
+func linkedRowIDs(row entities.TableRow, column *entities.TableColumn) []int64 {
+	value, ok := row[column.ID].(string)
+	if !ok {
+		return nil
+	}
+	ids, err := entities.ParseLinkedRowIDs(value)
+	if err != nil {
+		return nil
+	}
+	return ids
+}
+
+// resolveLinkedRecords returns the linked rows with their values of the column. Deleted rows
+// and rows the reader cannot access are skipped, so they show up again once restored.
+func resolveLinkedRecords(
+	row entities.TableRow,
+	linkColumn *entities.TableColumn,
+	linkedRows map[int64]entities.TableRow,
+	columnID string,
+) []*entities.LinkedRecord {
+	ids := linkedRowIDs(row, linkColumn)
+	records := make([]*entities.LinkedRecord, 0, len(ids))
+	for _, id := range ids {
+		linkedRow, ok := linkedRows[id]
+		if !ok {
+			continue
+		}
+
+		record := &entities.LinkedRecord{ID: id}
+		if value, ok := linkedRow[columnID].(string); ok {
+			record.Value = &value
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func (s *service) ListLinkingTableIDs(ctx context.Context, table *entities.Table) ([]string, error) {
+	databaseTables, err := s.repo.ListByDatabaseID(ctx, table.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	for _, databaseTable := range databaseTables {
+		if databaseTable.ID == table.ID {
+			continue
+		}
+		for _, col := range databaseTable.Columns {
+			if col.DeletedAt == nil && col.Type == entities.ColumnTypeLink && col.Link != nil && col.Link.TableID == table.ID {
+				ids = append(ids, databaseTable.ID)
+				break
+			}
+		}
+	}
+	return ids, nil
+}
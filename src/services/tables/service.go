@@ -8,6 +8,7 @@ import (
 	"backend/src/services"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ const (
 type service struct {
 	executor         sql_executor.ISQLExecutor
 	repo             repositories.ITablesRepository
+	usersTablesRepo  repositories.IUsersTablesRepository
 	changelogService services.IChangelogService
 	fileService      services.IFileService
 	keyMutex         key_mutex.IKeyMutex
@@ -32,83 +34,277 @@ type service struct {
 func NewService(
 	executor sql_executor.ISQLExecutor,
 	repo repositories.ITablesRepository,
+	usersTablesRepo repositories.IUsersTablesRepository,
 	changelogService services.IChangelogService,
 	fileService services.IFileService,
 ) services.ITablesService {
 	return &service{
 		executor:         executor,
 		repo:             repo,
+		usersTablesRepo:  usersTablesRepo,
 		changelogService: changelogService,
 		fileService:      fileService,
 		keyMutex:         key_mutex.NewKeyMutex(),
 	}
 }
 
-func (s *service) CreateTable(ctx context.Context, table *entities.Table) (*entities.Table, error) {
+func (s *service) CreateTable(ctx context.Context, userID int64, table *entities.Table) (*entities.Table, error) {
 	table.ID = fmt.Sprintf(tableIDTemplate, genUUID())
 
 	for _, col := range table.Columns {
+		// lookups and rollups cannot be created with the table, as they refer to its column IDs
+		if err := s.validateReferences(ctx, table, col); err != nil {
+			return nil, err
+		}
 		col.ID = fmt.Sprintf(columnIDTemplate, genUUID())
 	}
-
-	_, err := s.executor.Exec(ctx, table.CreateExpression())
-	if err != nil {
-		return nil, err
+	// formulas refer to the columns by name, so they are parsed once every column has its ID
+	for _, col := range table.Columns {
+		if err := parseFormula(table, col); err != nil {
+			return nil, err
+		}
 	}
-
-	_, err = s.executor.Exec(ctx, table.CreateSortIndexExpression())
-	if err != nil {
+	if err := compileFormulas(table); err != nil {
 		return nil, err
 	}
 
-	for _, col := range table.Columns {
-		_, err = s.executor.Exec(ctx, table.CreateColumnIndexExpression(col.ID))
+	var createdTable *entities.Table
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.executor.Exec(ctx, table.CreateExpression()); err != nil {
+			return err
+		}
+		if _, err := s.executor.Exec(ctx, table.CreateSortIndexExpression()); err != nil {
+			return err
+		}
+		for _, col := range table.Columns {
+			if _, err := s.executor.Exec(ctx, table.CreateColumnIndexExpression(col.ID)); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		createdTable, err = s.repo.AddTable(ctx, table)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		return s.writeTableAdded(ctx, userID, createdTable)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.repo.AddTable(ctx, table)
+	return createdTable, nil
 }
 
-func (s *service) ImportTable(ctx context.Context, name string, databaseID int64, columns []string, data [][]*string) (*entities.Table, error) {
+func (s *service) ImportTable(
+	ctx context.Context,
+	userID int64,
+	name string,
+	databaseID int64,
+	columns []string,
+	data [][]*string,
+	multiSelectColumns []string,
+) (*entities.Table, error) {
 	rowsLimitPerInsert := 60000 / (len(columns) + 1)
 	if rowsLimitPerInsert < 1 {
 		return nil, fmt.Errorf("too many columns")
 	}
 
 	table := genDefaultTable(databaseID, name, columns)
+	for _, columnName := range multiSelectColumns {
+		if err := importMultiSelectColumn(table, columnName, data); err != nil {
+			return nil, err
+		}
+	}
+
+	var createdTable *entities.Table
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.executor.Exec(ctx, table.CreateExpression()); err != nil {
+			return err
+		}
+		if _, err := s.executor.Exec(ctx, table.CreateSortIndexExpression()); err != nil {
+			return err
+		}
+
+		for i := 0; i < len(data); i += rowsLimitPerInsert {
+			end := i + rowsLimitPerInsert
+			if end > len(data) {
+				end = len(data)
+			}
+
+			if err := s.repo.AddFullFilledRows(ctx, table, userID, data[i:end]); err != nil {
+				return err
+			}
+		}
 
-	_, err := s.executor.Exec(ctx, table.CreateExpression())
+		var err error
+		createdTable, err = s.repo.AddTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		return s.writeTableAdded(ctx, userID, createdTable)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.executor.Exec(ctx, table.CreateSortIndexExpression())
-	if err != nil {
-		return nil, err
+	return createdTable, nil
+}
+
+// writeTableAdded logs the creation of the table by the user, in the transaction creating it.
+func (s *service) writeTableAdded(ctx context.Context, userID int64, table *entities.Table) error {
+	tableChange := &entities.TableChange{
+		ChangeType: entities.ChangeTypeAdd,
+		After:      entities.NewTableInfoForChangelog(table),
 	}
+	return s.changelogService.WriteChangelog(ctx, tableChange.ToChangelogItem(userID, table.DatabaseID, table.ID))
+}
 
-	for i := 0; i < len(data); i += rowsLimitPerInsert {
-		end := i + rowsLimitPerInsert
-		if end > len(data) {
-			end = len(data)
+func (s *service) DeleteTable(ctx context.Context, userID int64, tableID string) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		table, err := s.GetTableByID(ctx, tableID, false)
+		if err != nil {
+			return err
 		}
 
-		if err := s.repo.AddFullFilledRows(ctx, table, data[i:end]); err != nil {
-			return nil, err
+		if err := s.repo.DeleteTable(ctx, tableID); err != nil {
+			return err
 		}
-	}
 
-	return s.repo.AddTable(ctx, table)
+		tableChange := &entities.TableChange{
+			ChangeType: entities.ChangeTypeDelete,
+			Before:     entities.NewTableInfoForChangelog(table),
+		}
+		return s.changelogService.WriteChangelog(ctx, tableChange.ToChangelogItem(userID, table.DatabaseID, table.ID))
+	})
 }
 
-func (s *service) DeleteTable(ctx context.Context, tableID string) error {
-	return s.repo.DeleteTable(ctx, tableID)
+func (s *service) RestoreTable(ctx context.Context, userID int64, tableID string) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		table, err := s.GetTableByID(ctx, tableID, true)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.RestoreTable(ctx, tableID); err != nil {
+			return err
+		}
+
+		tableChange := &entities.TableChange{
+			ChangeType: entities.ChangeTypeRestore,
+			After:      entities.NewTableInfoForChangelog(table),
+		}
+		return s.changelogService.WriteChangelog(ctx, tableChange.ToChangelogItem(userID, table.DatabaseID, table.ID))
+	})
 }
 
-func (s *service) RestoreTable(ctx context.Context, tableID string) error {
-	return s.repo.RestoreTable(ctx, tableID)
+func (s *service) MoveTable(ctx context.Context, id string, databaseID int64) (*entities.Table, error) {
+	table, err := s.GetTableByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if table.DatabaseID == databaseID {
+		return nil, ErrorSameDatabase{}
+	}
+	// links never cross databases, so the table moves alone
+	if linksOtherTables(table) {
+		return nil, ErrorTableHasLinks{}
+	}
+	linkingTableIDs, err := s.ListLinkingTableIDs(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	if len(linkingTableIDs) > 0 {
+		return nil, ErrorTableHasLinks{}
+	}
+
+	table.DatabaseID = databaseID
+	table.DropMemberPolicies()
+	err = s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateTable(ctx, table); err != nil {
+			return err
+		}
+		// overrides belong to members of the source database
+		return s.usersTablesRepo.DeleteTableUsers(ctx, table.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+func (s *service) CopyTable(
+	ctx context.Context,
+	userID int64,
+	id string,
+	databaseID int64,
+	name string,
+	withData, withChangelog bool,
+) (*entities.Table, error) {
+	source, err := s.GetTableByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if source.DatabaseID != databaseID && linksOtherTables(source) {
+		return nil, ErrorTableHasLinks{}
+	}
+
+	table := &entities.Table{
+		ID:         fmt.Sprintf(tableIDTemplate, genUUID()),
+		Name:       name,
+		DatabaseID: databaseID,
+		Columns:    make([]*entities.TableColumn, 0, len(source.Columns)),
+	}
+	columnIDs := make(map[string]string, len(source.Columns))
+	for _, col := range source.Columns {
+		if col.DeletedAt != nil {
+			continue
+		}
+		column := *col
+		column.ID = fmt.Sprintf(columnIDTemplate, genUUID())
+		column.Policies = nil
+		table.Columns = append(table.Columns, &column)
+		columnIDs[col.ID] = column.ID
+	}
+	remapReferences(table, source.ID, columnIDs)
+
+	var createdTable *entities.Table
+	err = s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.executor.Exec(ctx, table.CreateExpression()); err != nil {
+			return err
+		}
+		if _, err := s.executor.Exec(ctx, table.CreateSortIndexExpression()); err != nil {
+			return err
+		}
+		for _, col := range table.Columns {
+			if _, err := s.executor.Exec(ctx, table.CreateColumnIndexExpression(col.ID)); err != nil {
+				return err
+			}
+		}
+
+		if withData {
+			if err := s.repo.CopyRows(ctx, source, table, columnIDs); err != nil {
+				return err
+			}
+		}
+		// row changes refer to row IDs, which only stay valid when the rows are copied too
+		if withData && withChangelog {
+			if err := s.changelogService.CopyTableChangelog(ctx, source.ID, table.ID, columnIDs); err != nil {
+				return err
+			}
+		}
+
+		createdTable, err = s.repo.AddTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		return s.writeTableAdded(ctx, userID, createdTable)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdTable, nil
 }
 
 func (s *service) AddColumnToTable(ctx context.Context, column *entities.TableColumn, tableID string) (*entities.Table, error) {
@@ -117,8 +313,18 @@ func (s *service) AddColumnToTable(ctx context.Context, column *entities.TableCo
 		return nil, err
 	}
 
+	if err := s.validateReferences(ctx, table, column); err != nil {
+		return nil, err
+	}
+	if err := parseFormula(table, column); err != nil {
+		return nil, err
+	}
+
 	column.ID = fmt.Sprintf(columnIDTemplate, genUUID())
 	table.Columns = append(table.Columns, column)
+	if err := compileFormulas(table); err != nil {
+		return nil, err
+	}
 
 	_, err = s.executor.Exec(ctx, table.AddColumnExpression(column))
 	if err != nil {
@@ -143,15 +349,33 @@ func (s *service) EditTableColumn(ctx context.Context, column *entities.TableCol
 		return nil, false, err
 	}
 
+	if err := parseFormula(table, column); err != nil {
+		return nil, false, err
+	}
+
 	updated := false
+	becameBoolean := false
 	for _, col := range table.Columns {
 		if col.ID == column.ID {
 			if !col.NeedToBeUpdated(column) {
 				break
 			}
+			if column.IsComputed() && table.FiltersRowsBy(column.ID) {
+				return nil, false, ErrorComputedRowPredicate{}
+			}
+			becameBoolean = col.Type != entities.ColumnTypeBoolean && column.Type == entities.ColumnTypeBoolean
+			if err := s.validateReferences(ctx, table, column); err != nil {
+				return nil, false, err
+			}
 			col.Name = column.Name
 			col.Type = column.Type
 			col.Enum = column.Enum
+			col.Policies = column.Policies
+			col.Link = column.Link
+			col.Lookup = column.Lookup
+			col.Rollup = column.Rollup
+			col.Formula = column.Formula
+			col.Autonumber = column.Autonumber
 			updated = true
 			break
 		}
@@ -161,13 +385,42 @@ func (s *service) EditTableColumn(ctx context.Context, column *entities.TableCol
 		return table, false, nil
 	}
 
-	if err := s.repo.UpdateTable(ctx, table); err != nil {
+	if err := compileFormulas(table); err != nil {
+		return nil, false, err
+	}
+
+	err = s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateTable(ctx, table); err != nil {
+			return err
+		}
+		if becameBoolean {
+			return s.normalizeColumnValues(ctx, table.ID, column)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, false, err
 	}
 
 	return table, true, nil
 }
 
+// normalizeColumnValues rewrites the stored values of the column the way they are written, see TableColumn.NormalizeColumnValue.
+func (s *service) normalizeColumnValues(ctx context.Context, tableID string, column *entities.TableColumn) error {
+	values, err := s.repo.GetDistinctValues(ctx, tableID, column.ID)
+	if err != nil {
+		return err
+	}
+
+	replacements := make(map[string]string)
+	for _, value := range values {
+		if normalized := column.NormalizeColumnValue(value); value != nil && *normalized != *value {
+			replacements[*value] = *normalized
+		}
+	}
+	return s.repo.ReplaceColumnValues(ctx, tableID, column.ID, replacements)
+}
+
 func (s *service) DeleteColumn(ctx context.Context, columnID string, tableID string) (*entities.Table, error) {
 	table, err := s.repo.GetTableByID(ctx, tableID, false)
 	if err != nil {
@@ -189,6 +442,11 @@ func (s *service) DeleteColumn(ctx context.Context, columnID string, tableID str
 		return nil, ErrorColumnNotFound{}
 	}
 
+	// formulas using the column would lose it
+	if err := compileFormulas(table); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.UpdateTable(ctx, table); err != nil {
 		return nil, err
 	}
@@ -202,17 +460,27 @@ func (s *service) RestoreColumn(ctx context.Context, columnID string, tableID st
 		return nil, err
 	}
 
-	found := false
+	var restored *entities.TableColumn
 	for _, col := range table.Columns {
 		if col.ID == columnID {
 			col.DeletedAt = nil
-			found = true
+			restored = col
 		}
 	}
 
-	if !found {
+	if restored == nil {
 		return nil, ErrorColumnNotFound{}
 	}
+	// the linked table may have been deleted or moved since the column was deleted
+	if restored.Type == entities.ColumnTypeLink {
+		if err := s.validateReferences(ctx, table, restored); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := compileFormulas(table); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.UpdateTable(ctx, table); err != nil {
 		return nil, err
@@ -242,7 +510,15 @@ func (s *service) ListByDatabaseIDs(ctx context.Context, databaseIDs []int64) ([
 }
 
 func (s *service) AddRow(ctx context.Context, userID int64, table *entities.Table, data map[string]*string, sortIndex *int64) (entities.TableRow, error) {
-	newRow, err := s.repo.AddRow(ctx, table, data, sortIndex)
+	var newRow entities.TableRow
+	err := s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		newRow, err = s.repo.AddRow(ctx, table, userID, data, sortIndex)
+		if err != nil {
+			return err
+		}
+		return s.checkRowAccessible(ctx, table, newRow.GetID())
+	})
 	if err != nil {
 		return entities.TableRow{}, err
 	}
@@ -288,16 +564,22 @@ func (s *service) MoveRow(ctx context.Context, tableID string, rowID int64, sort
 	return s.repo.MoveRow(ctx, tableID, rowID, sortIndex)
 }
 
-func (s *service) SetCellValue(ctx context.Context, userID int64, tableID string, rowID int64, columnID string, value *string) error {
-	rawChangeInfo, err := s.repo.SetCellValue(ctx, tableID, rowID, columnID, value)
-	if err != nil {
-		if s.repo.IsErrNoRows(err) {
-			return nil
+func (s *service) SetCellValue(ctx context.Context, userID int64, table *entities.Table, rowID int64, columnID string, value *string) error {
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		rawChangeInfo, err := s.repo.SetCellValue(ctx, table.ID, rowID, columnID, value, userID)
+		if err != nil {
+			if s.repo.IsErrNoRows(err) {
+				return nil
+			}
+			return err
+		}
+
+		if err := s.checkRowAccessible(ctx, table, rowID); err != nil {
+			return err
 		}
-		return err
-	}
 
-	return s.changelogService.WriteChangelog(ctx, rawChangeInfo.ToChangelogItem(userID, tableID, rowID, columnID, value))
+		return s.changelogService.WriteChangelog(ctx, rawChangeInfo.ToChangelogItem(userID, table.ID, rowID, columnID, value))
+	})
 }
 
 func (s *service) ReadTable(ctx context.Context, table *entities.Table, params entities.ReadTableParams) ([]entities.TableRow, error) {
@@ -308,12 +590,70 @@ func (s *service) GetTotalRows(ctx context.Context, table *entities.Table, param
 	return s.repo.GetTotalRows(ctx, table, &params)
 }
 
-func (s *service) ExportTable(ctx context.Context, table *entities.Table) (*excelize.File, error) {
+func (s *service) IsRowAccessible(ctx context.Context, table *entities.Table, rowID int64) (bool, error) {
+	if table.RowFilter() == nil {
+		return true, nil
+	}
+	return s.repo.IsRowAccessible(ctx, table, rowID)
+}
+
+func (s *service) FilterAccessibleRowIDs(ctx context.Context, table *entities.Table, ids []int64) ([]int64, error) {
+	if table.RowFilter() == nil || len(ids) == 0 {
+		return ids, nil
+	}
+	return s.repo.FilterAccessibleRowIDs(ctx, table, ids)
+}
+
+// checkRowAccessible fails with ErrorRowOutsidePolicies when the written row no longer passes
+// the row policies of the table view, rolling back the transaction the write was made in.
+func (s *service) checkRowAccessible(ctx context.Context, table *entities.Table, rowID int64) error {
+	accessible, err := s.IsRowAccessible(ctx, table, rowID)
+	if err != nil {
+		return err
+	}
+	if !accessible {
+		return ErrorRowOutsidePolicies{}
+	}
+	return nil
+}
+
+func (s *service) SetRowPolicies(ctx context.Context, tableID string, policies []*entities.RowPolicy) (*entities.Table, error) {
+	table, err := s.repo.GetTableByID(ctx, tableID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policies {
+		for _, predicate := range policy.Predicates {
+			column := table.GetColumn(predicate.ColumnID)
+			if column == nil {
+				return nil, ErrorColumnNotFound{}
+			}
+			// predicates compare the stored cells, computed columns only have values when read
+			if column.IsComputed() {
+				return nil, ErrorComputedRowPredicate{}
+			}
+		}
+	}
+
+	table.RowPolicies = policies
+	if err := s.repo.UpdateTable(ctx, table); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+func (s *service) ExportTable(ctx context.Context, table *entities.Table, linkedView entities.LinkedTableView) (*excelize.File, error) {
 	rows, err := s.repo.ReadTable(ctx, table, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.ResolveReferences(ctx, table, rows, linkedView); err != nil {
+		return nil, err
+	}
+
 	return s.fileService.CreateExcel(table, rows)
 }
 
@@ -345,6 +685,50 @@ func genUUID() string {
 	return strings.ReplaceAll(uuid.New().String(), "-", "")
 }
 
+// importMultiSelectColumn turns the imported text column into a multi-select one, with the options
+// selected in its cells, and normalises the cells the way they are exported.
+// Columns without any selected option stay text, as multi-select columns need options.
+func importMultiSelectColumn(table *entities.Table, columnName string, data [][]*string) error {
+	idx := slices.IndexFunc(table.Columns, func(col *entities.TableColumn) bool {
+		return col.Name == columnName
+	})
+	if idx < 0 {
+		return ErrorColumnNotFound{}
+	}
+
+	options := make([]string, 0)
+	for _, row := range data {
+		if idx >= len(row) || row[idx] == nil {
+			continue
+		}
+		selected := entities.ParseMultiSelect(*row[idx])
+		for _, option := range selected {
+			if !slices.Contains(options, option) {
+				options = append(options, option)
+			}
+		}
+
+		if len(selected) == 0 {
+			row[idx] = nil
+		} else {
+			row[idx] = pointer.To(entities.FormatMultiSelect(selected))
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	column := table.Columns[idx]
+	column.Type = entities.ColumnTypeMultiSelect
+	column.Enum = options
+	for _, row := range data {
+		if idx < len(row) && !column.ValidateColumnValue(row[idx]) {
+			return &ErrorInvalidColumnValue{Value: row[idx]}
+		}
+	}
+	return nil
+}
+
 func genDefaultTable(databaseID int64, name string, columns []string) *entities.Table {
 	table := &entities.Table{
 		ID:         fmt.Sprintf(tableIDTemplate, genUUID()),
@@ -12,3 +12,47 @@ func IsErrUserNotFound(err error) bool {
 	target := ErrorUserNotFound{}
 	return errors.As(err, &target)
 }
+
+type ErrorInvalidToken struct{}
+
+func (e ErrorInvalidToken) Error() string {
+	return "Invalid or expired token"
+}
+
+func IsErrInvalidToken(err error) bool {
+	target := ErrorInvalidToken{}
+	return errors.As(err, &target)
+}
+
+type ErrorEmailAlreadyTaken struct{}
+
+func (e ErrorEmailAlreadyTaken) Error() string {
+	return "Email is already taken by another account"
+}
+
+func IsErrEmailAlreadyTaken(err error) bool {
+	target := ErrorEmailAlreadyTaken{}
+	return errors.As(err, &target)
+}
+
+type ErrorEmailRequired struct{}
+
+func (e ErrorEmailRequired) Error() string {
+	return "Identity provider did not share the email"
+}
+
+func IsErrEmailRequired(err error) bool {
+	target := ErrorEmailRequired{}
+	return errors.As(err, &target)
+}
+
+type ErrorWrongPassword struct{}
+
+func (e ErrorWrongPassword) Error() string {
+	return "Wrong password"
+}
+
+func IsErrWrongPassword(err error) bool {
+	target := ErrorWrongPassword{}
+	return errors.As(err, &target)
+}
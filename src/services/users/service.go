@@ -3,22 +3,78 @@ package users
 import (
 	"backend/src/domains/entities"
 	"backend/src/domains/repositories"
+	"backend/src/modules/password_hasher"
+	"backend/src/modules/secure_token"
+	"backend/src/modules/sql_executor"
 	"backend/src/services"
 	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	passwordResetTokenExpirationTime     = time.Hour
+	emailVerificationTokenExpirationTime = 3 * 24 * time.Hour
+	emailChangeTokenExpirationTime       = 24 * time.Hour
 )
 
 type service struct {
-	repo repositories.IUsersRepository
+	executor                 sql_executor.ISQLExecutor
+	repo                     repositories.IUsersRepository
+	tokensRepo               repositories.IUserTokensRepository
+	identitiesRepo           repositories.IUserIdentitiesRepository
+	sessionsRepo             repositories.ISessionsRepository
+	personalAccessTokensRepo repositories.IPersonalAccessTokensRepository
+	databases                services.IDatabasesService
+	organizations            services.IOrganizationsService
+	changelogService         services.IChangelogService
+	passwordHasher           password_hasher.IPasswordHasher
+	mailer                   services.IMailer
+	appURL                   string
 }
 
-func NewService(repo repositories.IUsersRepository) services.IUsersService {
+func NewService(
+	executor sql_executor.ISQLExecutor,
+	repo repositories.IUsersRepository,
+	tokensRepo repositories.IUserTokensRepository,
+	identitiesRepo repositories.IUserIdentitiesRepository,
+	sessionsRepo repositories.ISessionsRepository,
+	personalAccessTokensRepo repositories.IPersonalAccessTokensRepository,
+	databases services.IDatabasesService,
+	organizations services.IOrganizationsService,
+	changelogService services.IChangelogService,
+	passwordHasher password_hasher.IPasswordHasher,
+	mailer services.IMailer,
+) services.IUsersService {
 	return &service{
-		repo: repo,
+		executor:                 executor,
+		repo:                     repo,
+		tokensRepo:               tokensRepo,
+		identitiesRepo:           identitiesRepo,
+		sessionsRepo:             sessionsRepo,
+		personalAccessTokensRepo: personalAccessTokensRepo,
+		databases:                databases,
+		organizations:            organizations,
+		changelogService:         changelogService,
+		passwordHasher:           passwordHasher,
+		mailer:                   mailer,
+		appURL:                   strings.TrimRight(os.Getenv("APP_URL"), "/"),
 	}
 }
 
 func (s *service) AddUser(ctx context.Context, user *entities.User) (*entities.User, error) {
-	return s.repo.CreateUser(ctx, user)
+	hash, err := s.passwordHasher.Hash(user.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	userToCreate := *user
+	userToCreate.Password = hash
+	return s.repo.CreateUser(ctx, &userToCreate)
 }
 
 func (s *service) FindUserByEmail(ctx context.Context, email string) (*entities.User, error) {
@@ -37,6 +93,381 @@ func (s *service) FindUserByID(ctx context.Context, id int64) (*entities.User, e
 	return res, err
 }
 
-func (s *service) ListUsers(ctx context.Context) ([]*entities.User, error) {
-	return s.repo.ListUsers(ctx)
+func (s *service) VerifyPassword(ctx context.Context, user *entities.User, password string) (bool, error) {
+	ok, err := s.passwordHasher.Compare(user.Password, password)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if !s.passwordHasher.NeedsRehash(user.Password) {
+		return true, nil
+	}
+
+	// Legacy plaintext or outdated cost: store a fresh hash, but never fail the login because of it.
+	hash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		log.Printf("Error rehashing password for user %d: %v", user.ID, err)
+		return true, nil
+	}
+	if err := s.repo.UpdateUserPassword(ctx, user.ID, hash); err != nil {
+		log.Printf("Error rehashing password for user %d: %v", user.ID, err)
+		return true, nil
+	}
+	user.Password = hash
+
+	return true, nil
+}
+
+func (s *service) SendEmailVerification(ctx context.Context, user *entities.User) error {
+	token, err := s.issueToken(ctx, user, user.Email, entities.UserTokenPurposeEmailVerification, emailVerificationTokenExpirationTime)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, &entities.MailMessage{
+		To:      user.Email,
+		Subject: "Подтверждение email в SimpleTable",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nЧтобы подтвердить адрес электронной почты, перейдите по ссылке:\n%s/verify-email?token=%s\n\nСсылка действительна %d ч.",
+			user.Name, s.appURL, url.QueryEscape(token), int(emailVerificationTokenExpirationTime.Hours()),
+		),
+	})
+}
+
+func (s *service) VerifyEmail(ctx context.Context, token string) (*entities.User, error) {
+	userToken, err := s.consumeToken(ctx, entities.UserTokenPurposeEmailVerification, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// The email might have been changed after the token was issued.
+	verified, err := s.repo.MarkEmailVerified(ctx, userToken.UserID, userToken.Email)
+	if err != nil {
+		return nil, err
+	}
+	if !verified {
+		return nil, ErrorInvalidToken{}
+	}
+
+	return s.FindUserByID(ctx, userToken.UserID)
+}
+
+func (s *service) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.FindUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.issueToken(ctx, user, user.Email, entities.UserTokenPurposePasswordReset, passwordResetTokenExpirationTime)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, &entities.MailMessage{
+		To:      user.Email,
+		Subject: "Восстановление пароля в SimpleTable",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nЧтобы задать новый пароль, перейдите по ссылке:\n%s/reset-password?token=%s\n\nСсылка действительна %d мин. Если вы не запрашивали восстановление пароля, просто проигнорируйте это письмо.",
+			user.Name, s.appURL, url.QueryEscape(token), int(passwordResetTokenExpirationTime.Minutes()),
+		),
+	})
+}
+
+func (s *service) ResetPassword(ctx context.Context, token string, password string) (*entities.User, error) {
+	userToken, err := s.consumeToken(ctx, entities.UserTokenPurposePasswordReset, token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.FindUserByID(ctx, userToken.UserID)
+	if err != nil {
+		if IsErrUserNotFound(err) {
+			return nil, ErrorInvalidToken{}
+		}
+		return nil, err
+	}
+	if user.Email != userToken.Email {
+		return nil, ErrorInvalidToken{}
+	}
+
+	hash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateUserPassword(ctx, user.ID, hash); err != nil {
+		return nil, err
+	}
+	if err := s.tokensRepo.InvalidateUserTokens(ctx, user.ID, entities.UserTokenPurposePasswordReset); err != nil {
+		return nil, err
+	}
+
+	// Receiving the letter proves the address belongs to the user.
+	if user.EmailVerifiedAt == nil {
+		if _, err := s.repo.MarkEmailVerified(ctx, user.ID, user.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+func (s *service) UpdateName(ctx context.Context, userID int64, name string) (*entities.User, error) {
+	user, err := s.repo.UpdateUserName(ctx, userID, name)
+	if err != nil && s.repo.IsErrNoRows(err) {
+		return nil, ErrorUserNotFound{}
+	}
+	return user, err
+}
+
+func (s *service) RequestEmailChange(ctx context.Context, user *entities.User, password string, email string) error {
+	if err := s.checkPassword(ctx, user, password); err != nil {
+		return err
+	}
+	if err := s.checkEmailIsFree(ctx, user.ID, email); err != nil {
+		return err
+	}
+
+	token, err := s.issueToken(ctx, user, email, entities.UserTokenPurposeEmailChange, emailChangeTokenExpirationTime)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, &entities.MailMessage{
+		To:      email,
+		Subject: "Смена email в SimpleTable",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nЧтобы использовать этот адрес для входа в SimpleTable, перейдите по ссылке:\n%s/confirm-email-change?token=%s\n\nСсылка действительна %d ч.",
+			user.Name, s.appURL, url.QueryEscape(token), int(emailChangeTokenExpirationTime.Hours()),
+		),
+	})
+}
+
+func (s *service) ConfirmEmailChange(ctx context.Context, token string) (*entities.User, error) {
+	userToken, err := s.consumeToken(ctx, entities.UserTokenPurposeEmailChange, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// The address could have been registered by someone else while the letter was on its way.
+	if err := s.checkEmailIsFree(ctx, userToken.UserID, userToken.Email); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.UpdateUserEmail(ctx, userToken.UserID, userToken.Email)
+	if err != nil {
+		if s.repo.IsErrNoRows(err) {
+			return nil, ErrorInvalidToken{}
+		}
+		return nil, err
+	}
+
+	if err := s.tokensRepo.InvalidateUserTokens(ctx, user.ID, entities.UserTokenPurposeEmailChange); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *service) ChangePassword(ctx context.Context, user *entities.User, currentPassword string, newPassword string) error {
+	if err := s.checkPassword(ctx, user, currentPassword); err != nil {
+		return err
+	}
+
+	hash, err := s.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdateUserPassword(ctx, user.ID, hash); err != nil {
+		return err
+	}
+
+	return s.tokensRepo.InvalidateUserTokens(ctx, user.ID, entities.UserTokenPurposePasswordReset)
+}
+
+func (s *service) DeleteUser(ctx context.Context, user *entities.User, password string) error {
+	if err := s.checkPassword(ctx, user, password); err != nil {
+		return err
+	}
+
+	return s.executor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.organizations.DeleteUsersMemberships(ctx, user.ID); err != nil {
+			return err
+		}
+
+		memberships, err := s.databases.DeleteUsersMemberships(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		changelog := make([]*entities.ChangelogItem, 0, len(memberships))
+		for _, membership := range memberships {
+			memberChange := entities.NewMemberChange(user.ID, membership.Role, "")
+			changelog = append(changelog, memberChange.ToChangelogItem(user.ID, membership.DatabaseID))
+		}
+		if len(changelog) > 0 {
+			if err := s.changelogService.WriteChangelog(ctx, changelog...); err != nil {
+				return err
+			}
+		}
+
+		// Identities are dropped so that signing in through SSO again starts a fresh account.
+		if err := s.identitiesRepo.DeleteUserIdentities(ctx, user.ID); err != nil {
+			return err
+		}
+
+		// Revoked together with the deletion, since authentication does not look up the user.
+		if err := s.sessionsRepo.RevokeUserSessions(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := s.personalAccessTokensRepo.RevokeUserTokens(ctx, user.ID); err != nil {
+			return err
+		}
+
+		// Changelog items keep pointing to the soft-deleted row, so the history stays attributed.
+		return s.repo.DeleteUser(ctx, user.ID)
+	})
+}
+
+func (s *service) checkPassword(ctx context.Context, user *entities.User, password string) error {
+	ok, err := s.VerifyPassword(ctx, user, password)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrorWrongPassword{}
+	}
+	return nil
+}
+
+func (s *service) checkEmailIsFree(ctx context.Context, userID int64, email string) error {
+	owner, err := s.FindUserByEmail(ctx, email)
+	if err != nil {
+		if IsErrUserNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if owner.ID != userID {
+		return ErrorEmailAlreadyTaken{}
+	}
+	return nil
+}
+
+func (s *service) ProvisionExternalUser(ctx context.Context, identity *entities.ExternalIdentity) (*entities.User, error) {
+	linked, err := s.identitiesRepo.GetIdentity(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		return s.FindUserByID(ctx, linked.UserID)
+	}
+	if !s.identitiesRepo.IsErrNoRows(err) {
+		return nil, err
+	}
+
+	if identity.Email == "" {
+		return nil, ErrorEmailRequired{}
+	}
+
+	user, err := s.FindUserByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		// Linking an existing account is only safe when both the provider and the account vouch for the address:
+		// otherwise whoever registered the address with a password would keep access to the linked account.
+		// The owner of an unverified account links it after verifying the email, or resetting the password.
+		if !identity.EmailVerified || user.EmailVerifiedAt == nil {
+			return nil, ErrorEmailAlreadyTaken{}
+		}
+	case IsErrUserNotFound(err):
+		user, err = s.createExternalUser(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	err = s.identitiesRepo.CreateIdentity(ctx, &entities.UserIdentity{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		UserID:  user.ID,
+		Email:   identity.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if identity.EmailVerified && user.EmailVerifiedAt == nil {
+		if _, err := s.repo.MarkEmailVerified(ctx, user.ID, user.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+func (s *service) createExternalUser(ctx context.Context, identity *entities.ExternalIdentity) (*entities.User, error) {
+	// The account is only reachable through SSO until the user resets the password.
+	password, err := secure_token.Generate("")
+	if err != nil {
+		return nil, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	return s.AddUser(ctx, &entities.User{
+		Name:     name,
+		Email:    identity.Email,
+		Password: password,
+	})
+}
+
+func (s *service) issueToken(
+	ctx context.Context,
+	user *entities.User,
+	email string,
+	purpose entities.UserTokenPurpose,
+	expirationTime time.Duration,
+) (string, error) {
+	token, err := secure_token.Generate("")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.tokensRepo.CreateToken(ctx, &entities.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: secure_token.Hash(token),
+		Email:     email,
+		ExpiresAt: time.Now().Add(expirationTime),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *service) consumeToken(ctx context.Context, purpose entities.UserTokenPurpose, token string) (*entities.UserToken, error) {
+	userToken, err := s.tokensRepo.ConsumeToken(ctx, purpose, secure_token.Hash(token))
+	if err != nil {
+		if s.tokensRepo.IsErrNoRows(err) {
+			return nil, ErrorInvalidToken{}
+		}
+		return nil, err
+	}
+	return userToken, nil
+}
+
+func (s *service) SearchUsers(ctx context.Context, callerID int64, params entities.SearchUsersParams) ([]*entities.User, int64, error) {
+	users, err := s.repo.SearchUsers(ctx, callerID, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.GetTotalSearchUsers(ctx, callerID, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
 }